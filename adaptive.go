@@ -0,0 +1,173 @@
+package main
+
+import (
+	"maps"
+	"slices"
+	"time"
+)
+
+// Shrink removes up to n mines from unrevealed cells, for adaptive difficulty when the player is struggling.
+// The mines are picked at random and the adjacency counts around them are updated. Revealed cells keep their state.
+// It returns the number of mines actually removed, which is less than n if there aren't enough unrevealed mines.
+func (b *Board) Shrink(n int) int {
+	var candidates [][2]int
+	for y := range b.Cells {
+		for x := range b.Cells[y] {
+			if b.Cells[y][x].IsMine && !b.Cells[y][x].Revealed {
+				candidates = append(candidates, [2]int{x, y})
+			}
+		}
+	}
+	b.shuffle(len(candidates), func(i, j int) {
+		candidates[i], candidates[j] = candidates[j], candidates[i]
+	})
+
+	removed := max(min(n, len(candidates)), 0)
+	for _, c := range candidates[:removed] {
+		b.Cells[c[1]][c[0]].IsMine = false
+		b.recalcAdjMinesAround(c[0], c[1])
+	}
+	b.TotalMines -= removed
+	return removed
+}
+
+// recalcAdjMinesAround recomputes the adjacent mine count of (x, y) and its neighbors after the mine at (x, y) changed.
+func (b *Board) recalcAdjMinesAround(x, y int) {
+	for _, c := range append(b.neighbors(x, y), [2]int{x, y}) {
+		cell := &b.Cells[c[1]][c[0]]
+		if cell.IsMine {
+			cell.AdjMines = 0
+		} else {
+			cell.AdjMines = b.countAdjMines(c[0], c[1])
+		}
+	}
+}
+
+// Grow adds up to n mines to unrevealed, non-mine cells, for adaptive difficulty when the player is winning too easily.
+// Mines are never placed on revealed cells. The adjacency counts around each new mine and TotalMines are updated.
+// It returns the number of mines actually added, which is less than n if the board runs out of hidden safe cells.
+func (b *Board) Grow(n int) int {
+	var candidates [][2]int
+	for y := range b.Cells {
+		for x := range b.Cells[y] {
+			if !b.Cells[y][x].IsMine && !b.Cells[y][x].Revealed {
+				candidates = append(candidates, [2]int{x, y})
+			}
+		}
+	}
+	b.shuffle(len(candidates), func(i, j int) {
+		candidates[i], candidates[j] = candidates[j], candidates[i]
+	})
+
+	added := max(min(n, len(candidates)), 0)
+	for _, c := range candidates[:added] {
+		b.Cells[c[1]][c[0]].IsMine = true
+		b.recalcAdjMinesAround(c[0], c[1])
+	}
+	b.TotalMines += added
+	return added
+}
+
+// ResizeBoard changes the board size by adding or removing columns on the right and rows at the bottom.
+// New cells are empty, hidden and safe. Mines in removed cells are dropped from TotalMines, and the adjacency counts along the seam are recomputed.
+// Notes, cell history and mine exclusions of removed cells are dropped. A won game goes back to StatePlaying if new cells were added,
+// and a game in progress is won if the removed cells were the last hidden safe ones.
+// It returns ErrInvalidSize for a non-positive size, or ErrWouldLoseRevealedCells if a revealed cell would be removed, leaving the board untouched.
+func (b *Board) ResizeBoard(newWidth, newHeight int) error {
+	if newWidth <= 0 || newHeight <= 0 {
+		return ErrInvalidSize
+	}
+	lost := b.CountCellsWhere(func(x, y int, cell Cell) bool {
+		return cell.Revealed && (x >= newWidth || y >= newHeight)
+	})
+	if lost > 0 {
+		return ErrWouldLoseRevealedCells
+	}
+
+	oldWidth, oldHeight := b.Width, b.Height
+	cells := make([][]Cell, newHeight)
+	for y := range cells {
+		cells[y] = make([]Cell, newWidth)
+		if y < oldHeight {
+			copy(cells[y], b.Cells[y])
+		}
+	}
+	b.Cells, b.Width, b.Height = cells, newWidth, newHeight
+	b.TotalMines = b.CountMines()
+	// Notes, history, exclusions and mine candidates for cells that were cut off go with them
+	offBoard := func(c [2]int) bool { return !b.isValidCell(c[0], c[1]) }
+	maps.DeleteFunc(b.Notes, func(c [2]int, _ string) bool { return offBoard(c) })
+	maps.DeleteFunc(b.history, func(c [2]int, _ []CellEvent) bool { return offBoard(c) })
+	maps.DeleteFunc(b.excluded, func(c [2]int, _ bool) bool { return offBoard(c) })
+	b.mineCandidates = slices.DeleteFunc(b.mineCandidates, offBoard)
+
+	// Only the cells next to where the board was cut or extended can have a different count
+	seamX, seamY := min(oldWidth, newWidth)-1, min(oldHeight, newHeight)-1
+	b.ForEachCellPtr(func(x, y int, cell *Cell) {
+		if x < seamX && y < seamY {
+			return
+		}
+		if cell.IsMine {
+			cell.AdjMines = 0
+		} else {
+			cell.AdjMines = b.countAdjMines(x, y)
+		}
+	})
+
+	// Growing a won board adds hidden safe cells and shrinking can cut off the last ones, so the state is derived again like Restore does.
+	// A lost game stays lost, as revealed cells are never cut off.
+	if b.State != StateLost {
+		if state := b.deriveState(); state == StatePlaying {
+			b.State, b.EndTime = StatePlaying, time.Time{}
+		} else {
+			b.endGame(state)
+		}
+	}
+	return nil
+}
+
+// GuaranteeAdjMines adds or removes mines around (x, y) until exactly count of its neighbors are mines, then recomputes the adjacency counts and TotalMines.
+// Only hidden neighbors are changed, and mines are never added to cells excluded with SetMineExclusion. Which neighbors change is random.
+// It returns ErrOutOfBounds for a cell off the board, or ErrCannotSatisfy if the count can't be reached, leaving the board untouched.
+func (b *Board) GuaranteeAdjMines(x, y, count int) error {
+	if !b.isValidCell(x, y) {
+		return cellError("GuaranteeAdjMines", x, y, ErrOutOfBounds)
+	}
+	current := 0
+	var addable, removable [][2]int
+	for _, n := range b.neighbors(x, y) {
+		cell := b.Cells[n[1]][n[0]]
+		if cell.IsMine {
+			current++
+		}
+		switch {
+		case cell.Revealed:
+		case cell.IsMine:
+			removable = append(removable, n)
+		case !b.excluded[n]:
+			addable = append(addable, n)
+		}
+	}
+
+	candidates, mine := addable, true
+	if count < current {
+		candidates, mine = removable, false
+	}
+	change := abs(count - current)
+	if count < 0 || change > len(candidates) {
+		return cellError("GuaranteeAdjMines", x, y, ErrCannotSatisfy)
+	}
+	b.shuffle(len(candidates), func(i, j int) {
+		candidates[i], candidates[j] = candidates[j], candidates[i]
+	})
+	for _, c := range candidates[:change] {
+		b.Cells[c[1]][c[0]].IsMine = mine
+	}
+
+	b.ForEachCellPtr(func(cx, cy int, cell *Cell) {
+		cell.AdjMines = 0
+	})
+	b.calculateAdjMines()
+	b.TotalMines = b.CountMines()
+	return nil
+}
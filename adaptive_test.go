@@ -0,0 +1,327 @@
+package main
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+// checkAdjacency fails the test if any adjacency count or TotalMines doesn't match the mines on the board.
+func checkAdjacency(t *testing.T, b *Board) {
+	t.Helper()
+	b.ForEachCell(func(x, y int, cell Cell) {
+		if !cell.IsMine && cell.AdjMines != b.countAdjMines(x, y) {
+			t.Errorf("cell (%d,%d) has AdjMines %d, want %d", x, y, cell.AdjMines, b.countAdjMines(x, y))
+		}
+	})
+	if mines := b.CountMines(); b.TotalMines != mines {
+		t.Errorf("TotalMines = %d, board has %d mines", b.TotalMines, mines)
+	}
+}
+
+func TestShrink(t *testing.T) {
+	tests := []struct {
+		name        string
+		n           int
+		wantRemoved int
+	}{
+		{"none", 0, 0},
+		{"some", 2, 2},
+		{"all hidden mines", 3, 3},
+		{"more than available", 10, 3},
+		{"negative", -1, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := boardFromTemplate(t, `
+M...M
+.....
+....M
+`)
+			b.RevealCell(1, 1)
+			revealed := b.RevealedMap()
+			if got := b.Shrink(tt.n); got != tt.wantRemoved {
+				t.Errorf("Shrink(%d) = %d, want %d", tt.n, got, tt.wantRemoved)
+			}
+			if b.TotalMines != 3-tt.wantRemoved {
+				t.Errorf("TotalMines = %d, want %d", b.TotalMines, 3-tt.wantRemoved)
+			}
+			checkAdjacency(t, b)
+			b.ForEachCell(func(x, y int, cell Cell) {
+				if cell.Revealed != revealed[y][x] {
+					t.Errorf("cell (%d,%d) revealed changed to %v", x, y, cell.Revealed)
+				}
+			})
+		})
+	}
+}
+
+func TestGrow(t *testing.T) {
+	tests := []struct {
+		name      string
+		n         int
+		wantAdded int
+	}{
+		{"none", 0, 0},
+		{"some", 3, 3},
+		{"every hidden safe cell", 13, 13},
+		{"more than available", 20, 13},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := boardFromTemplate(t, `
+.....
+.....
+....M
+`)
+			b.RevealCell(3, 1)
+			revealed := b.RevealedMap()
+			if got := b.Grow(tt.n); got != tt.wantAdded {
+				t.Errorf("Grow(%d) = %d, want %d", tt.n, got, tt.wantAdded)
+			}
+			if b.TotalMines != 1+tt.wantAdded {
+				t.Errorf("TotalMines = %d, want %d", b.TotalMines, 1+tt.wantAdded)
+			}
+			checkAdjacency(t, b)
+			b.ForEachCell(func(x, y int, cell Cell) {
+				if revealed[y][x] && cell.IsMine {
+					t.Errorf("mine placed on revealed cell (%d,%d)", x, y)
+				}
+			})
+		})
+	}
+}
+
+func TestAdaptiveUsesSeed(t *testing.T) {
+	const template = `
+M.M.M.M.
+.M.M.M.M
+M.M.M.M.
+.M.M.M.M
+`
+	tests := []struct {
+		name   string
+		adjust func(b *Board)
+	}{
+		{"Shrink", func(b *Board) { b.Shrink(8) }},
+		{"Grow", func(b *Board) { b.Grow(8) }},
+		{"GuaranteeAdjMines", func(b *Board) { b.GuaranteeAdjMines(2, 1, 6) }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var boards [2]*Board
+			for i := range boards {
+				boards[i] = boardFromTemplate(t, template)
+				WithSeed(5)(boards[i])
+				tt.adjust(boards[i])
+			}
+			if !slices.EqualFunc(boards[0].Cells, boards[1].Cells, slices.Equal[[]Cell]) {
+				t.Errorf("boards with the same seed differ after %s:\n%v\n%v", tt.name, boards[0].Cells, boards[1].Cells)
+			}
+		})
+	}
+}
+
+func TestResizeBoard(t *testing.T) {
+	tests := []struct {
+		name          string
+		width, height int
+		mines         int
+		adj           map[[2]int]int
+	}{
+		{"grow", 6, 5, 3, map[[2]int]int{{4, 0}: 1, {4, 3}: 0, {3, 3}: 1}},
+		{"grow right", 5, 3, 3, map[[2]int]int{{4, 0}: 1, {4, 1}: 1, {4, 2}: 0}},
+		{"shrink", 3, 3, 2, map[[2]int]int{{2, 0}: 0, {2, 1}: 1}},
+		{"shrink bottom", 4, 2, 2, map[[2]int]int{{1, 1}: 1, {3, 1}: 1}},
+		{"same size", 4, 3, 3, map[[2]int]int{{1, 1}: 2}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := boardFromTemplate(t, "M..M\n....\n..M.")
+			b.RevealCell(1, 1)
+			if err := b.ResizeBoard(tt.width, tt.height); err != nil {
+				t.Fatalf("ResizeBoard(%d, %d): %v", tt.width, tt.height, err)
+			}
+			if b.Width != tt.width || b.Height != tt.height || len(b.Cells) != tt.height || len(b.Cells[0]) != tt.width {
+				t.Fatalf("board is %dx%d with %dx%d cells, want %dx%d", b.Width, b.Height, len(b.Cells[0]), len(b.Cells), tt.width, tt.height)
+			}
+			if b.TotalMines != tt.mines {
+				t.Errorf("TotalMines = %d, want %d", b.TotalMines, tt.mines)
+			}
+			checkAdjacency(t, b)
+			for c, want := range tt.adj {
+				if got := b.Cells[c[1]][c[0]].AdjMines; got != want {
+					t.Errorf("cell %v has AdjMines %d, want %d", c, got, want)
+				}
+			}
+			if !b.Cells[1][1].Revealed || b.CountRevealed() != 1 {
+				t.Error("resizing changed which cells are revealed")
+			}
+		})
+	}
+}
+
+func TestResizeBoardState(t *testing.T) {
+	tests := []struct {
+		name          string
+		reveal        [][2]int
+		width, height int
+		want          GameState
+	}{
+		{"growing a won board", [][2]int{{2, 1}, {0, 1}}, 4, 2, StatePlaying},
+		{"adding a row to a won board", [][2]int{{2, 1}, {0, 1}}, 3, 3, StatePlaying},
+		{"won board at the same size", [][2]int{{2, 1}, {0, 1}}, 3, 2, StateWon},
+		{"cutting off the last hidden safe cells", [][2]int{{1, 0}, {0, 1}, {1, 1}}, 2, 2, StateWon},
+		{"cutting some and adding others", [][2]int{{1, 0}, {0, 1}, {1, 1}}, 2, 3, StatePlaying},
+		{"hidden safe cells left", [][2]int{{1, 0}}, 2, 2, StatePlaying},
+		{"lost board", [][2]int{{0, 0}}, 4, 4, StateLost},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := boardFromTemplate(t, "M..\n...")
+			for _, c := range tt.reveal {
+				b.RevealCell(c[0], c[1])
+			}
+			if err := b.ResizeBoard(tt.width, tt.height); err != nil {
+				t.Fatalf("ResizeBoard(%d, %d): %v", tt.width, tt.height, err)
+			}
+			if b.State != tt.want {
+				t.Errorf("State = %v, want %v", b.State, tt.want)
+			}
+			if ended := !b.EndTime.IsZero(); ended != (tt.want != StatePlaying) {
+				t.Errorf("EndTime = %v for a board in state %v", b.EndTime, b.State)
+			}
+		})
+	}
+}
+
+func TestResizeBoardPrunesCellData(t *testing.T) {
+	b := boardFromTemplate(t, "M..M\n....\n..M.")
+	EnableCellHistory()(b)
+	WithMineWaveStrategy(1, 1, 2)(b)
+	b.SetMineExclusion([][2]int{{0, 1}, {3, 2}})
+	for _, c := range [][2]int{{0, 1}, {3, 2}} {
+		b.AnnotateCell(c[0], c[1], "check")
+		b.FlagCell(c[0], c[1])
+	}
+	if err := b.ResizeBoard(3, 2); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		has  func(c [2]int) bool
+	}{
+		{"notes", func(c [2]int) bool { _, ok := b.Notes[c]; return ok }},
+		{"history", func(c [2]int) bool { _, ok := b.history[c]; return ok }},
+		{"excluded", func(c [2]int) bool { return b.excluded[c] }},
+		{"mine candidates", func(c [2]int) bool { return slices.Contains(b.mineCandidates, c) }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !tt.has([2]int{0, 1}) {
+				t.Errorf("%s lost the kept cell (0,1)", tt.name)
+			}
+			if tt.has([2]int{3, 2}) {
+				t.Errorf("%s still has the removed cell (3,2)", tt.name)
+			}
+		})
+	}
+	if len(b.mineCandidates) != 5 {
+		t.Errorf("%d mine candidates left, want the 5 ring cells on the 3x2 board", len(b.mineCandidates))
+	}
+}
+
+func TestResizeBoardErrors(t *testing.T) {
+	tests := []struct {
+		name          string
+		width, height int
+		want          error
+	}{
+		{"revealed column", 1, 3, ErrWouldLoseRevealedCells},
+		{"revealed row", 4, 1, ErrWouldLoseRevealedCells},
+		{"zero width", 0, 3, ErrInvalidSize},
+		{"negative height", 4, -1, ErrInvalidSize},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := boardFromTemplate(t, "M..M\n....\n..M.")
+			b.RevealCell(1, 1)
+			if err := b.ResizeBoard(tt.width, tt.height); !errors.Is(err, tt.want) {
+				t.Errorf("ResizeBoard(%d, %d) = %v, want %v", tt.width, tt.height, err, tt.want)
+			}
+			if b.Width != 4 || b.Height != 3 || b.TotalMines != 3 {
+				t.Errorf("board is %dx%d with %d mines after a failed resize", b.Width, b.Height, b.TotalMines)
+			}
+		})
+	}
+}
+
+func TestGuaranteeAdjMines(t *testing.T) {
+	const template = `
+M...
+.M..
+....
+...M
+`
+	tests := []struct {
+		name     string
+		x, y     int
+		count    int
+		reveal   [][2]int
+		excluded [][2]int
+		want     error
+	}{
+		{"add mines", 2, 2, 4, nil, nil, nil},
+		{"remove mines", 1, 0, 0, nil, nil, nil},
+		{"already satisfied", 1, 0, 2, nil, nil, nil},
+		{"every neighbor", 2, 2, 8, nil, nil, nil},
+		{"corner", 0, 3, 3, nil, nil, nil},
+		{"around a mine", 1, 1, 3, nil, nil, nil},
+		{"revealed neighbors are left alone", 3, 1, 4, [][2]int{{2, 2}}, nil, nil},
+		{"excluded neighbors are left alone", 2, 2, 6, nil, [][2]int{{2, 1}, {3, 2}}, nil},
+		{"more than 8", 2, 2, 9, nil, nil, ErrCannotSatisfy},
+		{"more than the corner's neighbors", 0, 3, 4, nil, nil, ErrCannotSatisfy},
+		{"negative", 2, 2, -1, nil, nil, ErrCannotSatisfy},
+		{"revealed neighbors can't take a mine", 3, 1, 5, [][2]int{{2, 2}}, nil, ErrCannotSatisfy},
+		{"excluded neighbors can't take a mine", 2, 2, 7, nil, [][2]int{{2, 1}, {3, 2}}, ErrCannotSatisfy},
+		{"off the board", 4, 0, 1, nil, nil, ErrOutOfBounds},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Which neighbors change is random, so try a few times
+			for i := 0; i < 10; i++ {
+				b := boardFromTemplate(t, template)
+				for _, c := range tt.reveal {
+					b.RevealCell(c[0], c[1])
+				}
+				b.SetMineExclusion(tt.excluded)
+				before := b.Clone()
+
+				err := b.GuaranteeAdjMines(tt.x, tt.y, tt.count)
+				if !errors.Is(err, tt.want) {
+					t.Fatalf("GuaranteeAdjMines(%d, %d, %d) = %v, want %v", tt.x, tt.y, tt.count, err, tt.want)
+				}
+				if err == nil && b.countAdjMines(tt.x, tt.y) != tt.count {
+					t.Errorf("(%d,%d) has %d adjacent mines, want %d", tt.x, tt.y, b.countAdjMines(tt.x, tt.y), tt.count)
+				}
+				b.ForEachCell(func(x, y int, cell Cell) {
+					if cell.IsMine == before.Cells[y][x].IsMine {
+						return
+					}
+					switch {
+					case err != nil:
+						t.Errorf("cell (%d,%d) changed after a failed call", x, y)
+					case abs(x-tt.x) > 1 || abs(y-tt.y) > 1 || (x == tt.x && y == tt.y):
+						t.Errorf("cell (%d,%d) changed but isn't a neighbor of (%d,%d)", x, y, tt.x, tt.y)
+					case cell.Revealed:
+						t.Errorf("revealed cell (%d,%d) changed", x, y)
+					case cell.IsMine && b.excluded[[2]int{x, y}]:
+						t.Errorf("excluded cell (%d,%d) got a mine", x, y)
+					}
+				})
+				checkAdjacency(t, b)
+			}
+		})
+	}
+}
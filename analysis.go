@@ -0,0 +1,587 @@
+package main
+
+import "sort"
+
+// neighbors returns the coordinates of the cells adjacent to (x, y) that are within the bounds of the board.
+func (b *Board) neighbors(x, y int) [][2]int {
+	result := make([][2]int, 0, 8)
+	for j := -1; j <= 1; j++ {
+		for i := -1; i <= 1; i++ {
+			if i == 0 && j == 0 {
+				continue
+			}
+			if b.isValidCell(x+i, y+j) {
+				result = append(result, [2]int{x + i, y + j})
+			}
+		}
+	}
+	return result
+}
+
+// AdjacentSafeUnrevealedCount returns the number of unrevealed, unflagged neighbors of (x, y).
+// For a revealed number these are the cells its remaining mines could still be in.
+func (b *Board) AdjacentSafeUnrevealedCount(x, y int) int {
+	count := 0
+	for _, n := range b.neighbors(x, y) {
+		if cell := b.Cells[n[1]][n[0]]; !cell.Revealed && !cell.Flagged {
+			count++
+		}
+	}
+	return count
+}
+
+// AdjacentFlaggedCount returns the number of flagged neighbors of (x, y).
+func (b *Board) AdjacentFlaggedCount(x, y int) int {
+	count := 0
+	for _, n := range b.neighbors(x, y) {
+		if b.Cells[n[1]][n[0]].Flagged {
+			count++
+		}
+	}
+	return count
+}
+
+// AdjacentRevealedCount returns the number of revealed neighbors of (x, y).
+func (b *Board) AdjacentRevealedCount(x, y int) int {
+	count := 0
+	for _, n := range b.neighbors(x, y) {
+		if b.Cells[n[1]][n[0]].Revealed {
+			count++
+		}
+	}
+	return count
+}
+
+// forcedMines returns the unrevealed cells that the revealed numbers prove to be mines.
+// A revealed cell showing N with exactly N unrevealed neighbors forces every one of those neighbors to be a mine.
+func (b *Board) forcedMines() map[[2]int]bool {
+	forced := make(map[[2]int]bool)
+	for y := range b.Cells {
+		for x := range b.Cells[y] {
+			cell := b.Cells[y][x]
+			if !cell.Revealed || cell.IsMine || cell.AdjMines == 0 {
+				continue
+			}
+			var unrevealed [][2]int
+			for _, n := range b.neighbors(x, y) {
+				if !b.Cells[n[1]][n[0]].Revealed {
+					unrevealed = append(unrevealed, n)
+				}
+			}
+			if len(unrevealed) == cell.AdjMines {
+				for _, n := range unrevealed {
+					forced[n] = true
+				}
+			}
+		}
+	}
+	return forced
+}
+
+// CriticalCells returns the mines that would end the game if revealed and that the player has no way of identifying yet.
+// Flagged mines are excluded as the player already knows about them, and so are mines forced by a revealed number.
+func (b *Board) CriticalCells() [][2]int {
+	forced := b.forcedMines()
+	var result [][2]int
+	for y := range b.Cells {
+		for x := range b.Cells[y] {
+			cell := b.Cells[y][x]
+			if !cell.IsMine || cell.Revealed || cell.Flagged || forced[[2]int{x, y}] {
+				continue
+			}
+			result = append(result, [2]int{x, y})
+		}
+	}
+	return result
+}
+
+// OpeningChains returns every opening on the board as a slice of points.
+// An opening is a connected region of zero-adj cells together with the numbered cells on its border, i.e. everything a single click on one of its zero cells would reveal.
+// Border cells can be shared by two openings, in which case they appear in both chains.
+func (b *Board) OpeningChains() [][]Point {
+	visited := make(map[[2]int]bool)
+	var chains [][]Point
+	for y := range b.Cells {
+		for x := range b.Cells[y] {
+			cell := b.Cells[y][x]
+			if cell.IsMine || cell.AdjMines != 0 || visited[[2]int{x, y}] {
+				continue
+			}
+
+			// BFS over the zero cells, collecting the numbered border as we go
+			inChain := map[[2]int]bool{{x, y}: true}
+			chain := []Point{{X: x, Y: y}}
+			visited[[2]int{x, y}] = true
+			queue := [][2]int{{x, y}}
+			for len(queue) > 0 {
+				current := queue[0]
+				queue = queue[1:]
+				for _, n := range b.neighbors(current[0], current[1]) {
+					neighbor := b.Cells[n[1]][n[0]]
+					if neighbor.IsMine || inChain[n] {
+						continue
+					}
+					inChain[n] = true
+					chain = append(chain, Point{X: n[0], Y: n[1]})
+					if neighbor.AdjMines == 0 {
+						visited[n] = true
+						queue = append(queue, n)
+					}
+				}
+			}
+			chains = append(chains, chain)
+		}
+	}
+	return chains
+}
+
+// FrontierCells returns the unrevealed, unflagged cells that are adjacent to at least one revealed cell.
+// These are the only cells the revealed numbers tell the player anything about.
+func (b *Board) FrontierCells() [][2]int {
+	return b.FilterCells(func(x, y int, cell Cell) bool {
+		return !cell.Revealed && !cell.Flagged && b.AdjacentRevealedCount(x, y) > 0
+	})
+}
+
+// SafeCells returns the unrevealed, unflagged cells that the revealed numbers prove to be safe.
+// A cell is safe if it's next to a revealed number whose mines are all accounted for, either by flags or by mines forced elsewhere (see forcedMines).
+func (b *Board) SafeCells() [][2]int {
+	forced := b.forcedMines()
+	known := func(c [2]int) bool {
+		return b.Cells[c[1]][c[0]].Flagged || forced[c]
+	}
+	return b.FilterCells(func(x, y int, cell Cell) bool {
+		if cell.Revealed || cell.Flagged || forced[[2]int{x, y}] {
+			return false
+		}
+		for _, n := range b.neighbors(x, y) {
+			neighbor := b.Cells[n[1]][n[0]]
+			if !neighbor.Revealed || neighbor.IsMine {
+				continue
+			}
+			mines := 0
+			for _, nn := range b.neighbors(n[0], n[1]) {
+				if known(nn) {
+					mines++
+				}
+			}
+			if mines == neighbor.AdjMines {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// MineProbability estimates the chance of each cell being a mine, based only on what the player can see.
+// Frontier cells take the highest ratio of remaining mines to unrevealed neighbors among the revealed numbers next to them.
+// Every other unrevealed cell gets the overall density of the unflagged mines left on the board.
+// Revealed and flagged cells are reported as 0.
+func (b *Board) MineProbability() [][]float64 {
+	probs := make([][]float64, b.Height)
+	for i := range probs {
+		probs[i] = make([]float64, b.Width)
+	}
+
+	mines, flags := b.CountMines(), b.CountFlags()
+	hidden := b.CountCellsWhere(func(x, y int, cell Cell) bool { return !cell.Revealed && !cell.Flagged })
+	density := 0.0
+	if hidden > 0 && mines > flags {
+		density = float64(mines-flags) / float64(hidden)
+	}
+
+	for y := range b.Cells {
+		for x := range b.Cells[y] {
+			cell := b.Cells[y][x]
+			if cell.Revealed || cell.Flagged {
+				continue
+			}
+			p, constrained := 0.0, false
+			for _, n := range b.neighbors(x, y) {
+				neighbor := b.Cells[n[1]][n[0]]
+				if !neighbor.Revealed || neighbor.IsMine {
+					continue
+				}
+				constrained = true
+				remaining := neighbor.AdjMines - b.AdjacentFlaggedCount(n[0], n[1])
+				if ratio := float64(remaining) / float64(b.AdjacentSafeUnrevealedCount(n[0], n[1])); ratio > p {
+					p = ratio
+				}
+			}
+			if !constrained {
+				p = density
+			}
+			if p > 1 {
+				p = 1
+			}
+			probs[y][x] = p
+		}
+	}
+	return probs
+}
+
+// NeighborMask returns the mines around (x, y) as an 8-bit mask, one bit per neighbor.
+// The bits are in reading order, starting from the least significant bit:
+//
+//	bit 0: top-left     bit 1: top     bit 2: top-right
+//	bit 3: left                        bit 4: right
+//	bit 5: bottom-left  bit 6: bottom  bit 7: bottom-right
+//
+// Neighbors outside the board are never set, so corner cells always have 5 bits clear and edge cells 3.
+func (b *Board) NeighborMask(x, y int) uint8 {
+	var mask uint8
+	bit := 0
+	for j := -1; j <= 1; j++ {
+		for i := -1; i <= 1; i++ {
+			if i == 0 && j == 0 {
+				continue
+			}
+			if b.isValidCell(x+i, y+j) && b.Cells[y+j][x+i].IsMine {
+				mask |= 1 << bit
+			}
+			bit++
+		}
+	}
+	return mask
+}
+
+// BoundingBox returns the smallest rectangle containing every revealed cell, as inclusive min and max coordinates.
+// If nothing has been revealed yet it returns all zeros and ErrNoCellsRevealed.
+func (b *Board) BoundingBox() (minX, minY, maxX, maxY int, err error) {
+	revealed := b.FilterCells(func(x, y int, cell Cell) bool { return cell.Revealed })
+	if len(revealed) == 0 {
+		return 0, 0, 0, 0, ErrNoCellsRevealed
+	}
+	minX, minY = revealed[0][0], revealed[0][1]
+	maxX, maxY = minX, minY
+	for _, c := range revealed[1:] {
+		minX, maxX = min(minX, c[0]), max(maxX, c[0])
+		minY, maxY = min(minY, c[1]), max(maxY, c[1])
+	}
+	return minX, minY, maxX, maxY, nil
+}
+
+// ConnectedRevealedComponent returns every revealed cell reachable from (x, y) by stepping between adjacent revealed cells, diagonals included.
+// Unlike the flood fill in RevealCell this ignores AdjMines, it only follows what is already revealed.
+// It returns nil if (x, y) is not a revealed cell.
+func (b *Board) ConnectedRevealedComponent(x, y int) [][2]int {
+	if !b.isValidCell(x, y) || !b.Cells[y][x].Revealed {
+		return nil
+	}
+	visited := map[[2]int]bool{{x, y}: true}
+	component := [][2]int{{x, y}}
+	for i := 0; i < len(component); i++ {
+		for _, n := range b.neighbors(component[i][0], component[i][1]) {
+			if visited[n] || !b.Cells[n[1]][n[0]].Revealed {
+				continue
+			}
+			visited[n] = true
+			component = append(component, n)
+		}
+	}
+	return component
+}
+
+// MineRings groups the mines by their distance from the nearest revealed safe cell, moving one cell at a time in any of the 8 directions.
+// The result is indexed by ring number: ring 1 holds the mines adjacent to a revealed cell, ring 2 those two steps away, and so on. Ring 0 is always empty.
+// It returns nil if no safe cell has been revealed yet.
+func (b *Board) MineRings() [][][2]int {
+	dist := make(map[[2]int]int)
+	queue := b.FilterCells(func(x, y int, cell Cell) bool { return cell.Revealed && !cell.IsMine })
+	if len(queue) == 0 {
+		return nil
+	}
+	for _, c := range queue {
+		dist[c] = 0
+	}
+
+	// Multi-source BFS from every revealed safe cell at once
+	var rings [][][2]int
+	for i := 0; i < len(queue); i++ {
+		current := queue[i]
+		for _, n := range b.neighbors(current[0], current[1]) {
+			if _, seen := dist[n]; seen {
+				continue
+			}
+			d := dist[current] + 1
+			dist[n] = d
+			queue = append(queue, n)
+			if b.Cells[n[1]][n[0]].IsMine {
+				for len(rings) <= d {
+					rings = append(rings, nil)
+				}
+				rings[d] = append(rings[d], n)
+			}
+		}
+	}
+	return rings
+}
+
+// CountUnrevealedSafeCells returns the number of safe cells the player still has to reveal.
+func (b *Board) CountUnrevealedSafeCells() int {
+	return b.CountCellsWhere(func(x, y int, cell Cell) bool { return !cell.IsMine && !cell.Revealed })
+}
+
+// SafetyScore returns the fraction of the remaining safe cells that are provably safe right now, from 0 to 1.
+// A high score means the player can keep going without guessing, a low one that a hint might be worth it.
+// A fresh board scores 0, and a board with nothing left to reveal scores 1.
+func (b *Board) SafetyScore() float64 {
+	remaining := b.CountUnrevealedSafeCells()
+	if remaining == 0 {
+		return 1
+	}
+	return float64(len(b.SafeCells())) / float64(remaining)
+}
+
+// SafetyRating describes how safe the player's next move is:
+// "Deterministic" if there is a provably safe cell to reveal (SafeCells isn't empty) or nothing left to reveal, otherwise a rating of the lowest mine probability
+// among the unrevealed cells: "Low Risk" below 20%, "Medium Risk" from 20% to 50% and "High Risk (Guess Required)" above 50%.
+func (b *Board) SafetyRating() string {
+	if len(b.SafeCells()) > 0 || b.CountUnrevealedSafeCells() == 0 {
+		return "Deterministic"
+	}
+	probs := b.MineProbability()
+	risk := 1.0
+	b.ForEachCell(func(x, y int, cell Cell) {
+		if !cell.Revealed && !cell.Flagged {
+			risk = min(risk, probs[y][x])
+		}
+	})
+	switch {
+	case risk < 0.2:
+		return "Low Risk"
+	case risk <= 0.5:
+		return "Medium Risk"
+	default:
+		return "High Risk (Guess Required)"
+	}
+}
+
+// constraintCounts returns, for every unrevealed unflagged cell, how many revealed numbers it is a neighbor of.
+func (b *Board) constraintCounts() map[[2]int]int {
+	counts := make(map[[2]int]int)
+	b.ForEachCell(func(x, y int, cell Cell) {
+		if cell.Revealed || cell.Flagged {
+			return
+		}
+		counts[[2]int{x, y}] = 0
+		for _, n := range b.neighbors(x, y) {
+			if neighbor := b.Cells[n[1]][n[0]]; neighbor.Revealed && !neighbor.IsMine && neighbor.AdjMines > 0 {
+				counts[[2]int{x, y}]++
+			}
+		}
+	})
+	return counts
+}
+
+// PressureCells returns the unrevealed, unflagged cells sorted by how many revealed numbers are waiting on them, most first.
+// Revealing a cell near the top of the list resolves the most constraints at once. Cells with the same count stay in row-major order,
+// and cells outside every constraint (count 0) come last.
+func (b *Board) PressureCells() [][2]int {
+	counts := b.constraintCounts()
+	cells := b.FilterCells(func(x, y int, cell Cell) bool { return !cell.Revealed && !cell.Flagged })
+	sort.SliceStable(cells, func(i, j int) bool {
+		return counts[cells[i]] > counts[cells[j]]
+	})
+	return cells
+}
+
+// ConstraintCount returns how many revealed numbers (x, y) is a neighbor of, the count PressureCells sorts by.
+// Revealed and flagged cells always return 0.
+func (b *Board) ConstraintCount(x, y int) int {
+	return b.constraintCounts()[[2]int{x, y}]
+}
+
+// MineNeighborhoodGraph connects mines that share at least one unrevealed safe neighbor, as these can often be deduced together.
+// Every mine is a key in the returned adjacency list, with a nil slice if it isn't connected to any other mine.
+func (b *Board) MineNeighborhoodGraph() map[[2]int][][2]int {
+	graph := make(map[[2]int][][2]int)
+	for _, mine := range b.FilterCells(func(x, y int, cell Cell) bool { return cell.IsMine }) {
+		graph[mine] = nil
+	}
+
+	// Collect the mines around each unrevealed safe cell, every pair of them is connected
+	linked := make(map[[2][2]int]bool)
+	b.ForEachCell(func(x, y int, cell Cell) {
+		if cell.IsMine || cell.Revealed {
+			return
+		}
+		var mines [][2]int
+		for _, n := range b.neighbors(x, y) {
+			if b.Cells[n[1]][n[0]].IsMine {
+				mines = append(mines, n)
+			}
+		}
+		for i, a := range mines {
+			for _, c := range mines[i+1:] {
+				if linked[[2][2]int{a, c}] {
+					continue
+				}
+				linked[[2][2]int{a, c}] = true
+				graph[a] = append(graph[a], c)
+				graph[c] = append(graph[c], a)
+			}
+		}
+	})
+	return graph
+}
+
+// HeatMap combines MineProbability with the constraint counts behind PressureCells into a danger score for every unrevealed cell.
+// The score is probability * (1 + constraintCount / maxConstraintCount), so a likely mine that many numbers agree on is the hottest.
+// Revealed and flagged cells score 0.
+func (b *Board) HeatMap() [][]float64 {
+	probs := b.MineProbability()
+	counts := b.constraintCounts()
+	maxCount := 0
+	for _, c := range counts {
+		maxCount = max(maxCount, c)
+	}
+
+	heat := make([][]float64, b.Height)
+	for y := range heat {
+		heat[y] = make([]float64, b.Width)
+	}
+	for c, count := range counts {
+		x, y := c[0], c[1]
+		heat[y][x] = probs[y][x]
+		if maxCount > 0 {
+			heat[y][x] *= 1 + float64(count)/float64(maxCount)
+		}
+	}
+	return heat
+}
+
+// NearestMine returns the mine closest to (x, y) and its Chebyshev distance, the number of king moves between the two cells.
+// The distance is 0 if (x, y) is itself a mine, ties go to the first mine in row-major order.
+// It returns ErrNoMines if the board has no mines.
+func (b *Board) NearestMine(x, y int) (Point, int, error) {
+	mines := b.FilterCells(func(x, y int, cell Cell) bool { return cell.IsMine })
+	if len(mines) == 0 {
+		return Point{}, 0, ErrNoMines
+	}
+	nearest, best := Point{}, -1
+	for _, m := range mines {
+		if d := max(abs(m[0]-x), abs(m[1]-y)); best == -1 || d < best {
+			nearest, best = Point{X: m[0], Y: m[1]}, d
+		}
+	}
+	return nearest, best, nil
+}
+
+// ConnectedMineGroups returns the connected components of mines, where two mines are connected if they are adjacent (diagonals included).
+// Groups are found in row-major order of their first mine, and each group lists its mines in BFS order from there.
+func (b *Board) ConnectedMineGroups() [][][2]int {
+	visited := make(map[[2]int]bool)
+	var groups [][][2]int
+	b.ForEachCell(func(x, y int, cell Cell) {
+		if !cell.IsMine || visited[[2]int{x, y}] {
+			return
+		}
+		visited[[2]int{x, y}] = true
+		group := [][2]int{{x, y}}
+		for i := 0; i < len(group); i++ {
+			for _, n := range b.neighbors(group[i][0], group[i][1]) {
+				if visited[n] || !b.Cells[n[1]][n[0]].IsMine {
+					continue
+				}
+				visited[n] = true
+				group = append(group, n)
+			}
+		}
+		groups = append(groups, group)
+	})
+	return groups
+}
+
+// MinePerimeter returns the number of non-mine cells touching at least one mine, i.e. the numbered cells.
+// The larger the perimeter, the more information the board gives away as it is revealed.
+func (b *Board) MinePerimeter() int {
+	return b.CountCellsWhere(func(x, y int, cell Cell) bool { return !cell.IsMine && cell.AdjMines > 0 })
+}
+
+// BFSDistance returns the fewest king moves from (x1, y1) to (x2, y2) stepping only on unrevealed, unflagged cells, endpoints included.
+// Revealed and flagged cells block the path. It returns (0, false) if there is no such path.
+func (b *Board) BFSDistance(x1, y1, x2, y2 int) (int, bool) {
+	open := func(c [2]int) bool {
+		return b.isValidCell(c[0], c[1]) && !b.Cells[c[1]][c[0]].Revealed && !b.Cells[c[1]][c[0]].Flagged
+	}
+	start, target := [2]int{x1, y1}, [2]int{x2, y2}
+	if !open(start) || !open(target) {
+		return 0, false
+	}
+	dist := map[[2]int]int{start: 0}
+	queue := [][2]int{start}
+	for i := 0; i < len(queue); i++ {
+		current := queue[i]
+		if current == target {
+			return dist[current], true
+		}
+		for _, n := range b.neighbors(current[0], current[1]) {
+			if _, seen := dist[n]; seen || !open(n) {
+				continue
+			}
+			dist[n] = dist[current] + 1
+			queue = append(queue, n)
+		}
+	}
+	return 0, false
+}
+
+// UnrevealedIslands returns the connected groups of unrevealed cells, flagged ones included, where diagonal neighbors count as connected.
+// A fresh board is a single island. Islands are found in row-major order of their first cell, and list their cells in BFS order.
+func (b *Board) UnrevealedIslands() [][][2]int {
+	visited := make(map[[2]int]bool)
+	var islands [][][2]int
+	b.ForEachCell(func(x, y int, cell Cell) {
+		if cell.Revealed || visited[[2]int{x, y}] {
+			return
+		}
+		visited[[2]int{x, y}] = true
+		island := [][2]int{{x, y}}
+		for i := 0; i < len(island); i++ {
+			for _, n := range b.neighbors(island[i][0], island[i][1]) {
+				if visited[n] || b.Cells[n[1]][n[0]].Revealed {
+					continue
+				}
+				visited[n] = true
+				island = append(island, n)
+			}
+		}
+		islands = append(islands, island)
+	})
+	return islands
+}
+
+// SurroundedMines returns the number of mines whose safe neighbors all have AdjMines == 8, i.e. are walled in by mines themselves.
+// No number on the board tells these mines apart from their neighbors, so they can't be deduced until late in the game.
+// A mine with no safe neighbors at all counts as surrounded too.
+func (b *Board) SurroundedMines() int {
+	return b.CountCellsWhere(func(x, y int, cell Cell) bool {
+		if !cell.IsMine {
+			return false
+		}
+		for _, n := range b.neighbors(x, y) {
+			if neighbor := b.Cells[n[1]][n[0]]; !neighbor.IsMine && neighbor.AdjMines != 8 {
+				return false
+			}
+		}
+		return true
+	})
+}
+
+// BestFlagTarget returns the frontier cell most likely to be a mine according to MineProbability, the counterpart of picking the safest guess.
+// Ties go to the cell more revealed numbers constrain (ConstraintCount), then to the first in row-major order.
+// It returns false if there is no frontier cell.
+func (b *Board) BestFlagTarget() (Point, bool) {
+	probs, counts := b.MineProbability(), b.constraintCounts()
+	best, found := Point{}, false
+	bestProb, bestCount := 0.0, 0
+	for _, c := range b.FrontierCells() {
+		p, count := probs[c[1]][c[0]], counts[c]
+		if found && (p < bestProb || (p == bestProb && count <= bestCount)) {
+			continue
+		}
+		best, found, bestProb, bestCount = Point{X: c[0], Y: c[1]}, true, p, count
+	}
+	return best, found
+}
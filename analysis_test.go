@@ -0,0 +1,691 @@
+package main
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestCriticalCells(t *testing.T) {
+	const template = `
+M.M
+...
+...
+M..
+`
+	tests := []struct {
+		name   string
+		reveal [][2]int
+		flag   [][2]int
+		want   [][2]int
+	}{
+		{"nothing known", nil, nil, [][2]int{{0, 0}, {2, 0}, {0, 3}}},
+		{"flagged mine excluded", nil, [][2]int{{0, 3}}, [][2]int{{0, 0}, {2, 0}}},
+		{"forced mines excluded", [][2]int{{1, 0}, {0, 1}, {1, 1}, {2, 1}}, nil, [][2]int{{0, 3}}},
+		{"nothing left", [][2]int{{1, 0}, {0, 1}, {1, 1}, {2, 1}}, [][2]int{{0, 3}}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := boardFromTemplate(t, template)
+			for _, c := range tt.reveal {
+				b.Cells[c[1]][c[0]].Revealed = true
+			}
+			for _, c := range tt.flag {
+				b.FlagCell(c[0], c[1])
+			}
+			if got := b.CriticalCells(); !slices.Equal(got, tt.want) {
+				t.Errorf("CriticalCells() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// sortedPoints returns a copy of the points sorted in row-major order, for comparing results whose order doesn't matter.
+func sortedPoints(points []Point) []Point {
+	sorted := slices.Clone(points)
+	slices.SortFunc(sorted, func(a, b Point) int {
+		if a.Y != b.Y {
+			return a.Y - b.Y
+		}
+		return a.X - b.X
+	})
+	return sorted
+}
+
+// sortedCells returns a copy of the coordinates sorted in row-major order.
+func sortedCells(cells [][2]int) [][2]int {
+	sorted := slices.Clone(cells)
+	slices.SortFunc(sorted, func(a, b [2]int) int {
+		if a[1] != b[1] {
+			return a[1] - b[1]
+		}
+		return a[0] - b[0]
+	})
+	return sorted
+}
+
+func TestOpeningChains(t *testing.T) {
+	b := boardFromTemplate(t, `
+...M...
+...M...
+...M...
+`)
+	var left, right []Point
+	for y := 0; y < 3; y++ {
+		for x := 0; x < 3; x++ {
+			left = append(left, Point{X: x, Y: y})
+			right = append(right, Point{X: x + 4, Y: y})
+		}
+	}
+
+	chains := b.OpeningChains()
+	if len(chains) != 2 {
+		t.Fatalf("got %d chains, want 2: %v", len(chains), chains)
+	}
+	for i, want := range [][]Point{sortedPoints(left), sortedPoints(right)} {
+		if got := sortedPoints(chains[i]); !slices.Equal(got, want) {
+			t.Errorf("chain %d = %v, want %v", i, got, want)
+		}
+	}
+}
+
+func TestOpeningChainsNoZeros(t *testing.T) {
+	b := boardFromTemplate(t, "M.M\n.M.")
+	if chains := b.OpeningChains(); len(chains) != 0 {
+		t.Errorf("OpeningChains() = %v, want none", chains)
+	}
+}
+
+func TestNeighborMask(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		x, y     int
+		want     uint8
+	}{
+		{"center", "M.M\n.M.\nM.M", 1, 1, 0b10100101},
+		{"top-left corner", "M.M\n.M.\nM.M", 0, 0, 0b10000000},
+		{"bottom-right corner", "M.M\n.M.\nM.M", 2, 2, 0b00000001},
+		{"top edge", "M.M\n.M.\nM.M", 1, 0, 0b01011000},
+		{"left edge", "M.M\n.M.\nM.M", 0, 1, 0b01010010},
+		{"corner of a full board", "MMM\nMMM\nMMM", 0, 0, 0b11010000},
+		{"edge of a full board", "MMM\nMMM\nMMM", 2, 1, 0b01101011},
+		{"no mines", "...\n...\n...", 1, 1, 0},
+		{"off the board", "MMM\nMMM\nMMM", 5, 5, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := boardFromTemplate(t, tt.template)
+			if got := b.NeighborMask(tt.x, tt.y); got != tt.want {
+				t.Errorf("NeighborMask(%d, %d) = %08b, want %08b", tt.x, tt.y, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBoundingBox(t *testing.T) {
+	tests := []struct {
+		name                   string
+		reveal                 [][2]int
+		minX, minY, maxX, maxY int
+		wantErr                error
+	}{
+		{"nothing revealed", nil, 0, 0, 0, 0, ErrNoCellsRevealed},
+		{"single cell", [][2]int{{2, 1}}, 2, 1, 2, 1, nil},
+		{"row", [][2]int{{1, 3}, {2, 3}, {3, 3}}, 1, 3, 3, 3, nil},
+		{"scattered", [][2]int{{4, 0}, {0, 2}, {2, 4}}, 0, 0, 4, 4, nil},
+		{"corner and center", [][2]int{{2, 2}, {4, 4}}, 2, 2, 4, 4, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := newEmptyBoard(5, 5)
+			for _, c := range tt.reveal {
+				b.Cells[c[1]][c[0]].Revealed = true
+			}
+			minX, minY, maxX, maxY, err := b.BoundingBox()
+			if err != tt.wantErr {
+				t.Errorf("error = %v, want %v", err, tt.wantErr)
+			}
+			if minX != tt.minX || minY != tt.minY || maxX != tt.maxX || maxY != tt.maxY {
+				t.Errorf("BoundingBox() = (%d, %d, %d, %d), want (%d, %d, %d, %d)", minX, minY, maxX, maxY, tt.minX, tt.minY, tt.maxX, tt.maxY)
+			}
+		})
+	}
+}
+
+func TestConnectedRevealedComponent(t *testing.T) {
+	// Two islands of revealed cells, the right one joined only diagonally
+	b := newEmptyBoard(6, 4)
+	for _, c := range [][2]int{{0, 0}, {1, 0}, {0, 1}, {3, 1}, {4, 2}, {5, 3}} {
+		b.Cells[c[1]][c[0]].Revealed = true
+	}
+	left := [][2]int{{0, 0}, {1, 0}, {0, 1}}
+	right := [][2]int{{3, 1}, {4, 2}, {5, 3}}
+
+	tests := []struct {
+		name string
+		x, y int
+		want [][2]int
+	}{
+		{"left island", 0, 1, left},
+		{"right island across diagonals", 5, 3, right},
+		{"middle of the right island", 4, 2, right},
+		{"hidden cell", 2, 2, nil},
+		{"off the board", 6, 0, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := b.ConnectedRevealedComponent(tt.x, tt.y); !slices.Equal(sortedCells(got), tt.want) {
+				t.Errorf("ConnectedRevealedComponent(%d, %d) = %v, want %v", tt.x, tt.y, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMineRings(t *testing.T) {
+	b := boardFromTemplate(t, `
+M....
+.MMM.
+.M.M.
+.MMM.
+....M
+`)
+	if rings := b.MineRings(); rings != nil {
+		t.Errorf("MineRings() before any reveal = %v, want nil", rings)
+	}
+
+	b.RevealCell(2, 2)
+	rings := b.MineRings()
+	want := [][][2]int{
+		nil,
+		{{1, 1}, {2, 1}, {3, 1}, {1, 2}, {3, 2}, {1, 3}, {2, 3}, {3, 3}},
+		{{0, 0}, {4, 4}},
+	}
+	if len(rings) != len(want) {
+		t.Fatalf("MineRings() has %d rings, want %d: %v", len(rings), len(want), rings)
+	}
+	for i := range want {
+		if got := sortedCells(rings[i]); !slices.Equal(got, want[i]) {
+			t.Errorf("ring %d = %v, want %v", i, got, want[i])
+		}
+	}
+}
+
+func TestSafetyScore(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		reveal   [][2]int
+		flag     [][2]int
+		want     float64
+	}{
+		{"fresh board", "M....\n.....\n....M", nil, nil, 0},
+		{"number without flags", ".M\n..", [][2]int{{0, 0}}, nil, 0},
+		{"one of two safe cells proven", "M...M", [][2]int{{1, 0}}, [][2]int{{0, 0}}, 0.5},
+		{"every safe cell proven", ".M\n..", [][2]int{{0, 0}}, [][2]int{{1, 0}}, 1},
+		{"won", "M..\n...", [][2]int{{2, 1}, {0, 1}}, nil, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := boardFromTemplate(t, tt.template)
+			for _, c := range tt.reveal {
+				b.RevealCell(c[0], c[1])
+			}
+			for _, c := range tt.flag {
+				b.FlagCell(c[0], c[1])
+			}
+			if got := b.SafetyScore(); got != tt.want {
+				t.Errorf("SafetyScore() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAdjacentCounts(t *testing.T) {
+	// R is revealed, F flagged, . hidden
+	layout := []string{
+		"RF.",
+		"R.F",
+		"..R",
+	}
+	b := newEmptyBoard(3, 3)
+	b.ForEachCellPtr(func(x, y int, cell *Cell) {
+		cell.Revealed = layout[y][x] == 'R'
+		cell.Flagged = layout[y][x] == 'F'
+	})
+	tests := []struct {
+		x, y                            int
+		safeUnrevealed, flagged, opened int
+	}{
+		{1, 1, 3, 2, 3},
+		{0, 0, 1, 1, 1},
+		{2, 2, 2, 1, 0},
+		{2, 0, 1, 2, 0},
+		{0, 2, 2, 0, 1},
+	}
+	for _, tt := range tests {
+		if got := b.AdjacentSafeUnrevealedCount(tt.x, tt.y); got != tt.safeUnrevealed {
+			t.Errorf("AdjacentSafeUnrevealedCount(%d, %d) = %d, want %d", tt.x, tt.y, got, tt.safeUnrevealed)
+		}
+		if got := b.AdjacentFlaggedCount(tt.x, tt.y); got != tt.flagged {
+			t.Errorf("AdjacentFlaggedCount(%d, %d) = %d, want %d", tt.x, tt.y, got, tt.flagged)
+		}
+		if got := b.AdjacentRevealedCount(tt.x, tt.y); got != tt.opened {
+			t.Errorf("AdjacentRevealedCount(%d, %d) = %d, want %d", tt.x, tt.y, got, tt.opened)
+		}
+	}
+}
+
+func TestPressureCells(t *testing.T) {
+	b := boardFromTemplate(t, `
+M.....
+......
+...M..
+`)
+	b.RevealCell(1, 1)
+	b.RevealCell(2, 1)
+	want := [][2]int{
+		{1, 0}, {2, 0}, {1, 2}, {2, 2},
+		{0, 0}, {3, 0}, {0, 1}, {3, 1}, {0, 2}, {3, 2},
+		{4, 0}, {5, 0}, {4, 1}, {5, 1}, {4, 2}, {5, 2},
+	}
+	if got := b.PressureCells(); !slices.Equal(got, want) {
+		t.Errorf("PressureCells() = %v, want %v", got, want)
+	}
+
+	tests := []struct {
+		x, y int
+		want int
+	}{
+		{1, 0, 2},
+		{3, 1, 1},
+		{5, 2, 0},
+		{1, 1, 0}, // revealed
+	}
+	for _, tt := range tests {
+		if got := b.ConstraintCount(tt.x, tt.y); got != tt.want {
+			t.Errorf("ConstraintCount(%d, %d) = %d, want %d", tt.x, tt.y, got, tt.want)
+		}
+	}
+}
+
+func TestMineNeighborhoodGraph(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		reveal   [][2]int
+		want     map[[2]int][][2]int
+	}{
+		{"adjacent mines and a distant one", "......\n.MM...\n......\n......\n.....M", nil, map[[2]int][][2]int{
+			{1, 1}: {{2, 1}},
+			{2, 1}: {{1, 1}},
+			{5, 4}: nil,
+		}},
+		{"two apart share a neighbor", "M.M\n...", nil, map[[2]int][][2]int{
+			{0, 0}: {{2, 0}},
+			{2, 0}: {{0, 0}},
+		}},
+		{"shared neighbors revealed", "M.M\n...", [][2]int{{1, 0}, {1, 1}}, map[[2]int][][2]int{
+			{0, 0}: nil,
+			{2, 0}: nil,
+		}},
+		{"chain", "M.M.M", nil, map[[2]int][][2]int{
+			{0, 0}: {{2, 0}},
+			{2, 0}: {{0, 0}, {4, 0}},
+			{4, 0}: {{2, 0}},
+		}},
+		{"no mines", "...", nil, map[[2]int][][2]int{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := boardFromTemplate(t, tt.template)
+			for _, c := range tt.reveal {
+				b.Cells[c[1]][c[0]].Revealed = true
+			}
+			got := b.MineNeighborhoodGraph()
+			if len(got) != len(tt.want) {
+				t.Fatalf("MineNeighborhoodGraph() = %v, want %v", got, tt.want)
+			}
+			for mine, want := range tt.want {
+				links, ok := got[mine]
+				if !ok || !slices.Equal(sortedCells(links), want) {
+					t.Errorf("links of %v = %v, want %v", mine, links, want)
+				}
+			}
+		})
+	}
+}
+
+func TestNearestMine(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		x, y     int
+		want     Point
+		dist     int
+	}{
+		{"surrounded by mines", "MMM\nM.M\nMMM", 1, 1, Point{0, 0}, 1},
+		{"corner with one mine", "....\n....\n...M", 0, 0, Point{3, 2}, 3},
+		{"cell is a mine", "M..\n..M", 2, 1, Point{2, 1}, 0},
+		{"closest of two", "M....\n....M", 3, 0, Point{4, 1}, 1},
+		{"tie goes to the first mine", "M...M", 2, 0, Point{0, 0}, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := boardFromTemplate(t, tt.template)
+			got, dist, err := b.NearestMine(tt.x, tt.y)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want || dist != tt.dist {
+				t.Errorf("NearestMine(%d, %d) = %v, %d, want %v, %d", tt.x, tt.y, got, dist, tt.want, tt.dist)
+			}
+		})
+	}
+}
+
+func TestNearestMineNoMines(t *testing.T) {
+	b := boardFromTemplate(t, "...\n...")
+	if _, _, err := b.NearestMine(1, 1); !errors.Is(err, ErrNoMines) {
+		t.Errorf("NearestMine() on a mine-free board = %v, want ErrNoMines", err)
+	}
+}
+
+func TestConnectedMineGroups(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		want     [][][2]int
+	}{
+		{"isolated mines", "M.M\n...\nM.M", [][][2]int{{{0, 0}}, {{2, 0}}, {{0, 2}}, {{2, 2}}}},
+		{"one cluster", ".....\n.MMM.\n.MMM.\n.....", [][][2]int{{{1, 1}, {2, 1}, {3, 1}, {1, 2}, {2, 2}, {3, 2}}}},
+		{"diagonal chain", "M...\n.M..\n..M.", [][][2]int{{{0, 0}, {1, 1}, {2, 2}}}},
+		{"mixed", "M...M\n.M..M\n.....\nM....", [][][2]int{{{0, 0}, {1, 1}}, {{4, 0}, {4, 1}}, {{0, 3}}}},
+		{"no mines", "...\n...", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := boardFromTemplate(t, tt.template).ConnectedMineGroups()
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %d groups, want %d: %v", len(got), len(tt.want), got)
+			}
+			for i := range got {
+				if g := sortedCells(got[i]); !slices.Equal(g, tt.want[i]) {
+					t.Errorf("group %d = %v, want %v", i, g, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestMinePerimeter(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		want     int
+	}{
+		{"single mine in the middle", ".....\n.....\n..M..\n.....\n.....", 8},
+		{"isolated cluster", "......\n......\n..MM..\n..MM..\n......\n......", 12},
+		{"cross-shaped cluster", ".......\n.......\n...M...\n..MMM..\n...M...\n.......\n.......", 16},
+		{"mine in a corner", "M..\n...\n...", 3},
+		{"every cell a mine", "MM\nMM", 0},
+		{"no mines", "...\n...", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := boardFromTemplate(t, tt.template).MinePerimeter(); got != tt.want {
+				t.Errorf("MinePerimeter() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBFSDistance(t *testing.T) {
+	tests := []struct {
+		name           string
+		reveal, flag   [][2]int
+		x1, y1, x2, y2 int
+		want           int
+		ok             bool
+	}{
+		{"same cell", nil, nil, 0, 0, 0, 0, 0, true},
+		{"adjacent", nil, nil, 0, 0, 1, 0, 1, true},
+		{"diagonal", nil, nil, 0, 0, 1, 1, 1, true},
+		{"across the board", nil, nil, 0, 0, 4, 2, 4, true},
+		{"around revealed cells", [][2]int{{1, 0}, {1, 1}}, nil, 0, 0, 2, 0, 4, true},
+		{"blocked by revealed cells", [][2]int{{1, 0}, {1, 1}, {1, 2}}, nil, 0, 0, 2, 0, 0, false},
+		{"blocked by flags", nil, [][2]int{{1, 0}, {1, 1}, {1, 2}}, 0, 0, 2, 0, 0, false},
+		{"start revealed", [][2]int{{1, 0}}, nil, 1, 0, 2, 0, 0, false},
+		{"target flagged", nil, [][2]int{{2, 0}}, 0, 0, 2, 0, 0, false},
+		{"off the board", nil, nil, 0, 0, 5, 0, 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := boardFromTemplate(t, "M.M.M\n.M.M.\nM.M.M")
+			for _, c := range tt.reveal {
+				b.RevealCell(c[0], c[1])
+			}
+			for _, c := range tt.flag {
+				b.FlagCell(c[0], c[1])
+			}
+			got, ok := b.BFSDistance(tt.x1, tt.y1, tt.x2, tt.y2)
+			if got != tt.want || ok != tt.ok {
+				t.Errorf("BFSDistance(%d, %d, %d, %d) = %d, %v, want %d, %v", tt.x1, tt.y1, tt.x2, tt.y2, got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+}
+
+func TestSafetyRating(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		reveal   [][2]int
+		flag     [][2]int
+		want     string
+	}{
+		{"every safe cell provable", "M.\n..", [][2]int{{1, 0}}, [][2]int{{0, 0}}, "Deterministic"},
+		{"nothing left to reveal", "M..", [][2]int{{2, 0}}, nil, "Deterministic"},
+		{"10% risk", "M.........", nil, nil, "Low Risk"},
+		{"just under 20%", "M.....", nil, nil, "Low Risk"},
+		{"20% is medium", "M....", nil, nil, "Medium Risk"},
+		{"50% is still medium", "M.", nil, nil, "Medium Risk"},
+		{"just over 50%", "MMMMMM.....", nil, nil, "High Risk (Guess Required)"},
+		{"60% risk", "MMM..", nil, nil, "High Risk (Guess Required)"},
+		{"frontier sets the risk", "M.\n..\n..", [][2]int{{1, 0}}, nil, "Medium Risk"},
+		// (1,1) and (2,1) are provably safe, even though every other hidden cell is a risky guess
+		{"a provably safe move exists", "MM..M\n...MM\nM....\n.....", [][2]int{{3, 3}}, nil, "Deterministic"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := boardFromTemplate(t, tt.template)
+			for _, c := range tt.reveal {
+				b.RevealCell(c[0], c[1])
+			}
+			for _, c := range tt.flag {
+				b.FlagCell(c[0], c[1])
+			}
+			if got := b.SafetyRating(); got != tt.want {
+				t.Errorf("SafetyRating() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUnrevealedIslands(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		reveal   [][2]int
+		sizes    []int
+	}{
+		{"fresh board", "M..\n...\n..M", nil, []int{9}},
+		{"one cell revealed", "M..\n...\n..M", [][2]int{{1, 1}}, []int{8}},
+		{"split by a revealed column", "M.M\nM.M\nM.M", [][2]int{{1, 0}, {1, 1}, {1, 2}}, []int{3, 3}},
+		{"pockets on both sides", "M...M\n.....\nM...M", [][2]int{{2, 1}}, []int{3, 3}},
+		{"nearly won", "M...M\n.....\n.....\n.....\nM...M", [][2]int{{2, 2}}, []int{1, 1, 1, 1}},
+		{"flagged cells are part of an island", "M.M\n...\n...", [][2]int{{0, 2}}, []int{3}},
+		{"won", "M..", [][2]int{{2, 0}}, []int{1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := boardFromTemplate(t, tt.template)
+			for _, c := range tt.reveal {
+				b.RevealCell(c[0], c[1])
+			}
+			b.FlagCell(0, 0)
+			islands := b.UnrevealedIslands()
+			sizes := make([]int, len(islands))
+			total := 0
+			for i, island := range islands {
+				sizes[i] = len(island)
+				total += len(island)
+				for _, c := range island {
+					if b.Cells[c[1]][c[0]].Revealed {
+						t.Errorf("island %d contains revealed cell %v", i, c)
+					}
+				}
+			}
+			if !slices.Equal(sizes, tt.sizes) {
+				t.Errorf("island sizes = %v, want %v", sizes, tt.sizes)
+			}
+			if want := b.Width*b.Height - b.CountRevealed(); total != want {
+				t.Errorf("islands cover %d cells, want %d", total, want)
+			}
+		})
+	}
+}
+
+func TestAdjacentRevealedCount(t *testing.T) {
+	tests := []struct {
+		name     string
+		revealed [][2]int
+		x, y     int
+		want     int
+	}{
+		{"corner, all revealed", nil, 0, 0, 3},
+		{"edge, all revealed", nil, 1, 0, 5},
+		{"center, all revealed", nil, 1, 1, 8},
+		{"far corner, all revealed", nil, 3, 2, 3},
+		{"corner, one neighbor", [][2]int{{1, 1}}, 0, 0, 1},
+		{"edge, two neighbors", [][2]int{{0, 0}, {2, 1}}, 1, 0, 2},
+		{"center, own cell doesn't count", [][2]int{{1, 1}}, 1, 1, 0},
+		{"center, none", [][2]int{{3, 2}}, 1, 1, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := newEmptyBoard(4, 3)
+			b.ForEachCellPtr(func(x, y int, cell *Cell) {
+				cell.Revealed = tt.revealed == nil || slices.Contains(tt.revealed, [2]int{x, y})
+			})
+			if got := b.AdjacentRevealedCount(tt.x, tt.y); got != tt.want {
+				t.Errorf("AdjacentRevealedCount(%d, %d) = %d, want %d", tt.x, tt.y, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFrontierCells(t *testing.T) {
+	// frontier is FrontierCells as it was written before AdjacentRevealedCount, checking the neighbors inline
+	frontier := func(b *Board) [][2]int {
+		var cells [][2]int
+		b.ForEachCell(func(x, y int, cell Cell) {
+			if cell.Revealed || cell.Flagged {
+				return
+			}
+			for _, n := range b.neighbors(x, y) {
+				if b.Cells[n[1]][n[0]].Revealed {
+					cells = append(cells, [2]int{x, y})
+					return
+				}
+			}
+		})
+		return cells
+	}
+	tests := []struct {
+		name   string
+		reveal [][2]int
+		flag   [][2]int
+	}{
+		{"fresh board", nil, nil},
+		{"single number", [][2]int{{1, 0}}, nil},
+		{"opening", [][2]int{{4, 4}}, nil},
+		{"flags are left out", [][2]int{{1, 0}}, [][2]int{{0, 0}, {0, 1}}},
+		{"several regions", [][2]int{{4, 4}, {1, 0}, {0, 4}}, [][2]int{{2, 2}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := boardFromTemplate(t, "M....\n.....\n..M..\n.M...\n.....")
+			for _, c := range tt.reveal {
+				b.RevealCell(c[0], c[1])
+			}
+			for _, c := range tt.flag {
+				b.FlagCell(c[0], c[1])
+			}
+			if got, want := b.FrontierCells(), frontier(b); !slices.Equal(got, want) {
+				t.Errorf("FrontierCells() = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestSurroundedMines(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		want     int
+	}{
+		{"no mines", "...\n...\n...", 0},
+		{"lone mine", "...\n.M.\n...", 0},
+		{"walled-in cell", "MMM\nM.M\nMMM", 8},
+		{"all mines", "MM\nMM", 4},
+		{"central cluster", ".....\n.MMM.\n.MMM.\n.MMM.\n.....", 1},
+		{"central cluster with a walled-in cell", ".......\n.MMMMM.\n.MMMMM.\n.MM.MM.\n.MMMMM.\n.MMMMM.\n.......", 8},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := boardFromTemplate(t, tt.template).SurroundedMines(); got != tt.want {
+				t.Errorf("SurroundedMines() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBestFlagTarget(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		reveal   [][2]int
+		flag     [][2]int
+		want     Point
+		found    bool
+	}{
+		{"fresh board has no frontier", "M.M..\n.....", nil, nil, Point{}, false},
+		{"every mine flagged", "M..\n...\n..M", [][2]int{{2, 0}, {0, 2}, {1, 1}}, [][2]int{{0, 0}, {2, 2}}, Point{}, false},
+		{"forced mine near the end", "M..\n...\n..M", [][2]int{{2, 0}, {0, 2}}, [][2]int{{2, 2}}, Point{0, 0}, true},
+		{"highest probability", "M.M..\n.....", [][2]int{{4, 0}, {1, 0}}, nil, Point{2, 0}, true},
+		{"tie goes to the most constrained cell", "MM.\n...\n...", [][2]int{{2, 2}}, nil, Point{1, 0}, true},
+		{"then to the first in row-major order", "..M.\n....\nM...", [][2]int{{1, 1}, {2, 1}}, nil, Point{1, 0}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := boardFromTemplate(t, tt.template)
+			for _, c := range tt.reveal {
+				b.RevealCell(c[0], c[1])
+			}
+			for _, c := range tt.flag {
+				b.FlagCell(c[0], c[1])
+			}
+			got, found := b.BestFlagTarget()
+			if got != tt.want || found != tt.found {
+				t.Errorf("BestFlagTarget() = %v, %v, want %v, %v", got, found, tt.want, tt.found)
+			}
+			probs := b.MineProbability()
+			for _, c := range b.FrontierCells() {
+				if probs[c[1]][c[0]] > probs[got.Y][got.X] {
+					t.Errorf("frontier cell %v has probability %v, more than the target's %v", c, probs[c[1]][c[0]], probs[got.Y][got.X])
+				}
+			}
+		})
+	}
+}
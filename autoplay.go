@@ -0,0 +1,50 @@
+package main
+
+// Strategy interface picks the next move for an automated player
+type Strategy interface {
+	NextMove(b *Board) Move
+}
+
+// ConstraintStrategy plays the solver's deductions, and guesses the cell with the lowest visible mine probability when there are none
+// The very first move has nothing to go on, so it opens a corner.
+type ConstraintStrategy struct{}
+
+// NextMove returns the next deduction, or the best guess if there is none.
+func (ConstraintStrategy) NextMove(b *Board) Move {
+	if step, ok := b.nextSolverStep(); ok {
+		return Move{Cmd: step.Action, X: step.Coord.X, Y: step.Coord.Y}
+	}
+	if b.CountRevealed() == 0 {
+		if corners := b.CornerStrategy(); len(corners) > 0 {
+			return Move{Cmd: CmdReveal, X: corners[0][0], Y: corners[0][1]}
+		}
+	}
+
+	probs := b.MineProbability()
+	best := Move{Cmd: CmdReveal, X: -1, Y: -1}
+	b.ForEachCell(func(x, y int, cell Cell) {
+		if cell.Revealed || cell.Flagged {
+			return
+		}
+		if best.X < 0 || probs[y][x] < probs[best.Y][best.X] {
+			best.X, best.Y = x, y
+		}
+	})
+	return best
+}
+
+// Solver struct plays games without a human
+type Solver struct{}
+
+// AutoPlay plays the game with the given strategy until it is won or lost, and returns true if it was won.
+// It also stops if the strategy runs out of moves, e.g. when every hidden cell is flagged.
+func (Solver) AutoPlay(g *Game, s Strategy) bool {
+	for g.Board.State == StatePlaying {
+		move := s.NextMove(g.Board)
+		if !g.Board.isValidCell(move.X, move.Y) || g.Play(move) != nil {
+			break
+		}
+	}
+	g.Finish()
+	return g.Board.State == StateWon
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/rand"
+	"slices"
+	"time"
+)
+
+// BenchmarkResult struct summarizes a batch of games played by the solver
+type BenchmarkResult struct {
+	Difficulty    string        `json:"difficulty"`
+	Games         int           `json:"games"`
+	Wins          int           `json:"wins"`
+	WinRate       float64       `json:"winRate"`
+	AverageTime   time.Duration `json:"averageTime"`
+	MedianTime    time.Duration `json:"medianTime"`
+	Average3BV    float64       `json:"average3BV"`
+	AvgEfficiency float64       `json:"averageEfficiency"`
+}
+
+// RunBenchmark plays n games of the given difficulty with Solver.AutoPlay and the ConstraintStrategy, stress-testing the solver and the board generator together.
+func RunBenchmark(n int, d Difficulty) BenchmarkResult {
+	return runBenchmark(n, d, rand.New(rand.NewSource(rand.Int63())))
+}
+
+// runBenchmark is RunBenchmark with the board of every game seeded from r, so a run can be repeated.
+func runBenchmark(n int, d Difficulty, r *rand.Rand) BenchmarkResult {
+	result := BenchmarkResult{Difficulty: d.String(), Games: n}
+	if n <= 0 {
+		return result
+	}
+
+	durations := make([]time.Duration, 0, n)
+	var total time.Duration
+	threeBV, efficiency := 0, 0.0
+	for i := 0; i < n; i++ {
+		width, height, mines := d.Params()
+		game := NewGame(NewBoard(width, height, mines, WithSeed(r.Int63())))
+		if (Solver{}).AutoPlay(game, ConstraintStrategy{}) {
+			result.Wins++
+		}
+		m := game.EndMetrics()
+		durations = append(durations, m.Duration)
+		total += m.Duration
+		threeBV += m.ThreeBV
+		efficiency += m.Efficiency
+	}
+
+	slices.Sort(durations)
+	result.WinRate = float64(result.Wins) / float64(n) * 100
+	result.AverageTime = total / time.Duration(n)
+	result.MedianTime = durations[n/2]
+	if n%2 == 0 {
+		result.MedianTime = (durations[n/2-1] + durations[n/2]) / 2
+	}
+	result.Average3BV = float64(threeBV) / float64(n)
+	result.AvgEfficiency = efficiency / float64(n)
+	return result
+}
+
+// Print writes the benchmark summary, as plain text or as a JSON object.
+func (r BenchmarkResult) Print(w io.Writer, asJSON bool) error {
+	if asJSON {
+		return json.NewEncoder(w).Encode(r)
+	}
+	fmt.Fprintf(w, "Games:          %d (%s)\n", r.Games, r.Difficulty)
+	fmt.Fprintf(w, "Win rate:       %.1f%%\n", r.WinRate)
+	fmt.Fprintf(w, "Average time:   %s\n", r.AverageTime)
+	fmt.Fprintf(w, "Median time:    %s\n", r.MedianTime)
+	fmt.Fprintf(w, "Average 3BV:    %.1f\n", r.Average3BV)
+	fmt.Fprintf(w, "Avg efficiency: %.2f\n", r.AvgEfficiency)
+	return nil
+}
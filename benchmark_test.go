@@ -0,0 +1,78 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"math/rand"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRunBenchmark(t *testing.T) {
+	result := runBenchmark(10, DifficultyBeginner, rand.New(rand.NewSource(1)))
+	if result.Games != 10 || result.Difficulty != "beginner" {
+		t.Fatalf("got %d %s games, want 10 beginner", result.Games, result.Difficulty)
+	}
+	if result.WinRate <= 50 {
+		t.Errorf("win rate = %.1f%%, want more than 50%%", result.WinRate)
+	}
+	if want := float64(result.Wins) * 10; result.WinRate != want {
+		t.Errorf("win rate = %.1f%% with %d wins, want %.1f%%", result.WinRate, result.Wins, want)
+	}
+	if result.Average3BV <= 0 || result.AvgEfficiency <= 0 {
+		t.Errorf("average 3BV = %v, efficiency = %v, want both above 0", result.Average3BV, result.AvgEfficiency)
+	}
+
+	again := runBenchmark(10, DifficultyBeginner, rand.New(rand.NewSource(1)))
+	if again.Wins != result.Wins || again.Average3BV != result.Average3BV {
+		t.Errorf("the same seed won %d games with 3BV %v, then %d with %v", result.Wins, result.Average3BV, again.Wins, again.Average3BV)
+	}
+}
+
+func TestRunBenchmarkNoGames(t *testing.T) {
+	if got := RunBenchmark(0, DifficultyBeginner); got != (BenchmarkResult{Difficulty: "beginner"}) {
+		t.Errorf("RunBenchmark(0) = %+v, want an empty result", got)
+	}
+}
+
+func TestBenchmarkResultPrint(t *testing.T) {
+	r := BenchmarkResult{
+		Difficulty:    "beginner",
+		Games:         4,
+		Wins:          3,
+		WinRate:       75,
+		AverageTime:   3 * time.Millisecond,
+		MedianTime:    2 * time.Millisecond,
+		Average3BV:    12.5,
+		AvgEfficiency: 0.8,
+	}
+	tests := []struct {
+		name   string
+		asJSON bool
+		want   []string
+	}{
+		{"text", false, []string{"Games:          4 (beginner)", "Win rate:       75.0%", "Median time:    2ms", "Average 3BV:    12.5", "Avg efficiency: 0.80"}},
+		{"json", true, []string{`"winRate":75`, `"average3BV":12.5`}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			if err := r.Print(&out, tt.asJSON); err != nil {
+				t.Fatal(err)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(out.String(), want) {
+					t.Errorf("output is missing %q:\n%s", want, out.String())
+				}
+			}
+			if !tt.asJSON {
+				return
+			}
+			var decoded BenchmarkResult
+			if err := json.Unmarshal(out.Bytes(), &decoded); err != nil || decoded != r {
+				t.Errorf("decoded %+v, %v, want %+v", decoded, err, r)
+			}
+		})
+	}
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"math/rand"
+	"time"
+)
+
+// Challenge mode timing: the window starts at 30 seconds and loses a second every 5 moves, down to 5 seconds
+const (
+	challengeStartWindow = 30 * time.Second
+	challengeMinWindow   = 5 * time.Second
+	challengeStep        = time.Second
+	challengeStepMoves   = 5
+)
+
+// ChallengeTimer struct gives the player an increasingly short time to make each move
+// Now is the clock the timer reads, it defaults to time.Now and can be swapped for a fake clock.
+type ChallengeTimer struct {
+	Now func() time.Time
+
+	moves    int
+	deadline time.Time
+	// windowMoves is the move count when the running window started
+	windowMoves int
+}
+
+// NewChallengeTimer creates a challenge timer on the real clock.
+func NewChallengeTimer() *ChallengeTimer {
+	return &ChallengeTimer{Now: time.Now}
+}
+
+// Window returns the time allowed for the current move.
+func (c *ChallengeTimer) Window() time.Duration {
+	window := challengeStartWindow - time.Duration(c.moves/challengeStepMoves)*challengeStep
+	return max(window, challengeMinWindow)
+}
+
+// Start starts the clock for the next move.
+// A window that is still running is kept until a move is made, so typing something that isn't a move doesn't buy the player more time.
+func (c *ChallengeTimer) Start() {
+	if !c.deadline.IsZero() && c.windowMoves == c.moves && !c.Expired() {
+		return
+	}
+	c.windowMoves = c.moves
+	c.deadline = c.Now().Add(c.Window())
+}
+
+// Remaining returns how long is left for the current move, 0 if the time is up.
+func (c *ChallengeTimer) Remaining() time.Duration {
+	return max(c.deadline.Sub(c.Now()), 0)
+}
+
+// Expired reports whether the time for the current move has run out.
+func (c *ChallengeTimer) Expired() bool {
+	return !c.Now().Before(c.deadline)
+}
+
+// MoveMade counts a move, which shrinks the window every few moves.
+func (c *ChallengeTimer) MoveMade() {
+	c.moves++
+}
+
+// CheckChallenge reveals a random unrevealed, unflagged cell if the player ran out of time for their move, which may well hit a mine.
+// It returns true if a cell was revealed. The forced reveal counts as the player's move.
+func (g *Game) CheckChallenge() bool {
+	if g.Challenge == nil || !g.Challenge.Expired() {
+		return false
+	}
+	cells := g.Board.FilterCells(func(x, y int, cell Cell) bool { return !cell.Revealed && !cell.Flagged })
+	if len(cells) == 0 {
+		return false
+	}
+	c := cells[rand.Intn(len(cells))]
+	g.Play(Move{Cmd: CmdReveal, X: c[0], Y: c[1]})
+	g.Challenge.MoveMade()
+	return true
+}
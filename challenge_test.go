@@ -0,0 +1,133 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeClock is a clock for tests that only moves when told to.
+type fakeClock struct {
+	t time.Time
+}
+
+func (c *fakeClock) Now() time.Time          { return c.t }
+func (c *fakeClock) Advance(d time.Duration) { c.t = c.t.Add(d) }
+
+func TestChallengeTimerWindow(t *testing.T) {
+	tests := []struct {
+		moves int
+		want  time.Duration
+	}{
+		{0, 30 * time.Second},
+		{4, 30 * time.Second},
+		{5, 29 * time.Second},
+		{14, 28 * time.Second},
+		{120, 6 * time.Second},
+		{125, 5 * time.Second},
+		{500, 5 * time.Second},
+	}
+	for _, tt := range tests {
+		c := &ChallengeTimer{Now: time.Now}
+		for i := 0; i < tt.moves; i++ {
+			c.MoveMade()
+		}
+		if got := c.Window(); got != tt.want {
+			t.Errorf("Window() after %d moves = %v, want %v", tt.moves, got, tt.want)
+		}
+	}
+}
+
+func TestChallengeTimerDeadline(t *testing.T) {
+	clock := &fakeClock{t: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	c := &ChallengeTimer{Now: clock.Now}
+	c.Start()
+	if c.Expired() || c.Remaining() != 30*time.Second {
+		t.Fatalf("fresh timer: Expired() = %v, Remaining() = %v", c.Expired(), c.Remaining())
+	}
+	clock.Advance(29 * time.Second)
+	if c.Expired() || c.Remaining() != time.Second {
+		t.Errorf("after 29s: Expired() = %v, Remaining() = %v, want false and 1s", c.Expired(), c.Remaining())
+	}
+	clock.Advance(time.Second)
+	if !c.Expired() || c.Remaining() != 0 {
+		t.Errorf("after 30s: Expired() = %v, Remaining() = %v, want true and 0", c.Expired(), c.Remaining())
+	}
+
+	// After 5 moves the next window is a second shorter
+	for i := 0; i < 5; i++ {
+		c.MoveMade()
+	}
+	c.Start()
+	clock.Advance(29 * time.Second)
+	if !c.Expired() {
+		t.Error("29s into a 29s window the timer hasn't expired")
+	}
+}
+
+func TestRunKeepsChallengeWindow(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  time.Duration
+	}{
+		{"unparseable input", "garbage\nquit\n", 20 * time.Second},
+		{"unknown command", "dig 1 1\nquit\n", 20 * time.Second},
+		{"off the board", "reveal 9 9\nquit\n", 20 * time.Second},
+		{"empty line", "\nquit\n", 20 * time.Second},
+		{"valid move starts a new window", "flag 1 1\nquit\n", 30 * time.Second},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clock := &fakeClock{t: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+			g := NewGame(boardFromTemplate(t, "M..\n..."))
+			g.Challenge = &ChallengeTimer{Now: clock.Now}
+			g.Challenge.Start()
+			clock.Advance(10 * time.Second)
+
+			g.Run(strings.NewReader(tt.input))
+			if got := g.Challenge.Remaining(); got != tt.want {
+				t.Errorf("Remaining() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckChallenge(t *testing.T) {
+	clock := &fakeClock{t: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	g := NewGame(boardFromTemplate(t, "M...\n....\n...M"))
+	g.Challenge = &ChallengeTimer{Now: clock.Now}
+	var reveals []Move
+	g.Subscribe(func(e Event) {
+		if m, ok := e.(MoveEvent); ok {
+			reveals = append(reveals, m.Move)
+		}
+	})
+
+	g.Challenge.Start()
+	clock.Advance(10 * time.Second)
+	if g.CheckChallenge() || len(reveals) != 0 {
+		t.Fatal("CheckChallenge revealed a cell before the time was up")
+	}
+
+	clock.Advance(20 * time.Second)
+	if !g.CheckChallenge() {
+		t.Fatal("CheckChallenge didn't reveal a cell after the time ran out")
+	}
+	if len(reveals) != 1 || reveals[0].Cmd != CmdReveal {
+		t.Fatalf("timeout played %v, want a single reveal", reveals)
+	}
+	if c := g.Board.Cells[reveals[0].Y][reveals[0].X]; !c.Revealed {
+		t.Errorf("auto-revealed cell (%d,%d) is still hidden", reveals[0].X, reveals[0].Y)
+	}
+	if g.Challenge.moves != 1 {
+		t.Errorf("the forced reveal counted as %d moves, want 1", g.Challenge.moves)
+	}
+}
+
+func TestCheckChallengeWithoutTimer(t *testing.T) {
+	g := NewGame(boardFromTemplate(t, "M."))
+	if g.CheckChallenge() {
+		t.Error("CheckChallenge revealed a cell in a game without a challenge timer")
+	}
+}
@@ -0,0 +1,89 @@
+package main
+
+import "time"
+
+// BoardCheckpoint struct is a lightweight snapshot of the player's progress on a board
+// The mine layout never changes during a game, so unlike Clone only the Revealed and Flagged bits are stored, packed two bits per cell in row-major order.
+type BoardCheckpoint struct {
+	bits []byte
+}
+
+// Size returns the size of the checkpoint in bytes, ceil(Width*Height*2 / 8).
+func (c BoardCheckpoint) Size() int {
+	return len(c.bits)
+}
+
+// Checkpoint takes a snapshot of which cells are revealed and flagged.
+func (b *Board) Checkpoint() BoardCheckpoint {
+	bits := make([]byte, (b.Width*b.Height*2+7)/8)
+	b.ForEachCell(func(x, y int, cell Cell) {
+		i := (y*b.Width + x) * 2
+		if cell.Revealed {
+			bits[i/8] |= 1 << (i % 8)
+		}
+		if cell.Flagged {
+			bits[(i+1)/8] |= 1 << ((i + 1) % 8)
+		}
+	})
+	return BoardCheckpoint{bits: bits}
+}
+
+// Restore puts the revealed and flagged state of every cell back to how it was when the checkpoint was taken.
+// The game state is worked out again from the restored cells, so restoring to before a mine was hit lets the game go on.
+// Question marks aren't stored, a restored flag clears any question mark on its cell.
+// The checkpoint must come from this board, a checkpoint of the wrong size is ignored.
+func (b *Board) Restore(c BoardCheckpoint) {
+	if len(c.bits) != (b.Width*b.Height*2+7)/8 {
+		return
+	}
+	b.ForEachCellPtr(func(x, y int, cell *Cell) {
+		cell.Revealed, cell.Flagged = c.cellBits(y*b.Width + x)
+		if cell.Flagged {
+			cell.Questioned = false
+		}
+	})
+	b.MineRevealed = b.hasRevealedMine()
+	b.State = b.deriveState()
+	if b.State == StatePlaying {
+		b.EndTime = time.Time{}
+	}
+}
+
+// cellBits decodes the revealed and flagged bits of the cell at the given row-major index.
+func (c BoardCheckpoint) cellBits(index int) (revealed, flagged bool) {
+	i := index * 2
+	revealed = c.bits[i/8]&(1<<(i%8)) != 0
+	flagged = c.bits[(i+1)/8]&(1<<((i+1)%8)) != 0
+	return revealed, flagged
+}
+
+// CheckpointDiff compares two checkpoints of this board and returns the cells that were revealed, flagged or unflagged from before to after.
+// Checkpoints don't store question marks, so NewlyQuestioned is always empty. Bytes that didn't change are skipped without decoding,
+// so unchanged stretches of the board cost one comparison per 4 cells. Checkpoints of the wrong size give an empty diff.
+func (b *Board) CheckpointDiff(before, after BoardCheckpoint) BoardDiff {
+	var diff BoardDiff
+	size := (b.Width*b.Height*2 + 7) / 8
+	if len(before.bits) != size || len(after.bits) != size {
+		return diff
+	}
+	for i := range after.bits {
+		if before.bits[i] == after.bits[i] {
+			continue
+		}
+		for index := i * 4; index < min(i*4+4, b.Width*b.Height); index++ {
+			wasRevealed, wasFlagged := before.cellBits(index)
+			revealed, flagged := after.cellBits(index)
+			coord := [2]int{index % b.Width, index / b.Width}
+			if revealed && !wasRevealed {
+				diff.NewlyRevealed = append(diff.NewlyRevealed, coord)
+			}
+			if flagged && !wasFlagged {
+				diff.NewlyFlagged = append(diff.NewlyFlagged, coord)
+			}
+			if !flagged && wasFlagged {
+				diff.NewlyUnflagged = append(diff.NewlyUnflagged, coord)
+			}
+		}
+	}
+	return diff
+}
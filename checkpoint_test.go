@@ -0,0 +1,189 @@
+package main
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestCheckpointSize(t *testing.T) {
+	tests := []struct {
+		width, height int
+		want          int
+	}{
+		{1, 1, 1},
+		{2, 2, 1},
+		{3, 3, 3},
+		{9, 9, 21},
+		{16, 16, 64},
+		{30, 16, 120},
+	}
+	for _, tt := range tests {
+		b := newEmptyBoard(tt.width, tt.height)
+		if got := b.Checkpoint().Size(); got != tt.want {
+			t.Errorf("%dx%d checkpoint size = %d, want %d", tt.width, tt.height, got, tt.want)
+		}
+	}
+}
+
+func TestCheckpointRestore(t *testing.T) {
+	b := boardFromTemplate(t, `
+M...
+....
+...M
+`)
+	b.FlagCell(0, 0)
+	saved := b.Checkpoint()
+	want := b.Clone()
+
+	b.RevealCell(2, 0)
+	b.FlagCell(0, 0)
+	b.QuestionCell(3, 1)
+	b.Restore(saved)
+
+	b.ForEachCell(func(x, y int, cell Cell) {
+		w := want.Cells[y][x]
+		if cell.Revealed != w.Revealed || cell.Flagged != w.Flagged {
+			t.Errorf("cell (%d,%d) revealed=%v flagged=%v, want revealed=%v flagged=%v", x, y, cell.Revealed, cell.Flagged, w.Revealed, w.Flagged)
+		}
+	})
+}
+
+func TestCheckpointRestoreAfterMineHit(t *testing.T) {
+	b := boardFromTemplate(t, `
+..M
+...
+`)
+	b.RevealCell(0, 0)
+	saved := b.Checkpoint()
+	b.RevealCell(2, 0)
+	if !b.IsTerminalState() {
+		t.Fatal("revealing a mine didn't end the game")
+	}
+
+	b.Restore(saved)
+	if b.State != StatePlaying {
+		t.Errorf("State = %v, want StatePlaying", b.State)
+	}
+	if b.MineRevealed || b.IsTerminalState() {
+		t.Error("board still reports the mine as revealed")
+	}
+	if !b.EndTime.IsZero() {
+		t.Errorf("EndTime = %v, want zero", b.EndTime)
+	}
+	if !b.CanReveal(2, 1) {
+		t.Error("CanReveal(2, 1) = false after restoring, want true")
+	}
+}
+
+func TestCheckpointRestoreClearsQuestionOnFlag(t *testing.T) {
+	b := boardFromTemplate(t, `
+.M
+..
+`)
+	b.FlagCell(1, 0)
+	saved := b.Checkpoint()
+	b.QuestionCell(1, 0)
+	b.Restore(saved)
+	if cell := b.Cells[0][1]; !cell.Flagged || cell.Questioned {
+		t.Errorf("cell (1,0) flagged=%v questioned=%v, want flagged and not questioned", cell.Flagged, cell.Questioned)
+	}
+}
+
+func TestCheckpointRestoreWrongSize(t *testing.T) {
+	b := boardFromTemplate(t, `
+.M
+..
+`)
+	other := newEmptyBoard(5, 5)
+	other.Cells[0][0].Revealed = true
+	b.Restore(other.Checkpoint())
+	if b.CountRevealed() != 0 {
+		t.Error("restoring a checkpoint of another size changed the board")
+	}
+}
+
+func TestCheckpointDiff(t *testing.T) {
+	const template = `
+M...
+....
+...M
+`
+	tests := []struct {
+		name      string
+		setup     func(b *Board)
+		play      func(b *Board)
+		revealed  [][2]int
+		flagged   [][2]int
+		unflagged [][2]int
+	}{
+		{"identical checkpoints", func(b *Board) { b.RevealCell(3, 0) }, func(b *Board) {}, nil, nil, nil},
+		{"cascading reveal", func(b *Board) {}, func(b *Board) { b.RevealCell(2, 0) },
+			[][2]int{{1, 0}, {2, 0}, {3, 0}, {1, 1}, {2, 1}, {3, 1}}, nil, nil},
+		{"single reveal", func(b *Board) { b.RevealCell(2, 0) }, func(b *Board) { b.RevealCell(0, 1) }, [][2]int{{0, 1}}, nil, nil},
+		{"flag and unflag", func(b *Board) { b.FlagCell(3, 2) }, func(b *Board) { b.FlagCell(3, 2); b.FlagCell(0, 0) },
+			nil, [][2]int{{0, 0}}, [][2]int{{3, 2}}},
+		{"question marks aren't stored", func(b *Board) {}, func(b *Board) { b.QuestionCell(1, 2) }, nil, nil, nil},
+		{"question mark over a flag", func(b *Board) { b.FlagCell(1, 2) }, func(b *Board) { b.QuestionCell(1, 2) }, nil, nil, [][2]int{{1, 2}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := boardFromTemplate(t, template)
+			tt.setup(b)
+			before, clone := b.Checkpoint(), b.Clone()
+			tt.play(b)
+			diff := b.CheckpointDiff(before, b.Checkpoint())
+
+			for _, f := range []struct {
+				name      string
+				got, want [][2]int
+			}{
+				{"NewlyRevealed", diff.NewlyRevealed, tt.revealed},
+				{"NewlyFlagged", diff.NewlyFlagged, tt.flagged},
+				{"NewlyUnflagged", diff.NewlyUnflagged, tt.unflagged},
+				{"NewlyQuestioned", diff.NewlyQuestioned, nil},
+			} {
+				if len(f.got) != len(f.want) || !slices.Equal(f.got, f.want) {
+					t.Errorf("%s = %v, want %v", f.name, f.got, f.want)
+				}
+			}
+			// Apart from question marks, the checkpoints tell the same story as the boards
+			full := DiffBoards(clone, b)
+			if !slices.Equal(diff.NewlyRevealed, full.NewlyRevealed) || !slices.Equal(diff.NewlyFlagged, full.NewlyFlagged) || !slices.Equal(diff.NewlyUnflagged, full.NewlyUnflagged) {
+				t.Errorf("CheckpointDiff() = %+v, DiffBoards() = %+v", diff, full)
+			}
+		})
+	}
+}
+
+func TestCheckpointDiffLargeBoard(t *testing.T) {
+	// 7x5 cells don't fill the last checkpoint byte, and moves land in several bytes
+	b := NewBoard(7, 5, 6, WithSeed(3))
+	before, clone := b.Checkpoint(), b.Clone()
+	b.ForEachCell(func(x, y int, cell Cell) {
+		switch {
+		case cell.IsMine && x%2 == 0:
+			b.FlagCell(x, y)
+		case !cell.IsMine && (x+y)%4 == 0:
+			b.RevealCell(x, y)
+		}
+	})
+	diff, full := b.CheckpointDiff(before, b.Checkpoint()), DiffBoards(clone, b)
+	if len(full.NewlyRevealed) == 0 {
+		t.Fatal("no cells were revealed")
+	}
+	if !slices.Equal(diff.NewlyRevealed, full.NewlyRevealed) || !slices.Equal(diff.NewlyFlagged, full.NewlyFlagged) || !slices.Equal(diff.NewlyUnflagged, full.NewlyUnflagged) {
+		t.Errorf("CheckpointDiff() = %+v, DiffBoards() = %+v", diff, full)
+	}
+}
+
+func TestCheckpointDiffWrongSize(t *testing.T) {
+	b := boardFromTemplate(t, "M.\n..")
+	before := b.Checkpoint()
+	b.RevealCell(1, 1)
+	other := newEmptyBoard(5, 5).Checkpoint()
+	for _, diff := range []BoardDiff{b.CheckpointDiff(before, other), b.CheckpointDiff(other, b.Checkpoint())} {
+		if diff.NewlyRevealed != nil || diff.NewlyFlagged != nil || diff.NewlyUnflagged != nil {
+			t.Errorf("diff against a checkpoint of another size = %+v, want empty", diff)
+		}
+	}
+}
@@ -0,0 +1,152 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+)
+
+// Constraint struct says that exactly MineCount of Cells are mines
+// Cells are unrevealed, unflagged cells in row-major order.
+type Constraint struct {
+	Cells     [][2]int
+	MineCount int
+}
+
+// BuildConstraints returns one constraint per revealed number that still has unrevealed, unflagged neighbors, in row-major order of the numbers.
+// Each constraint covers those neighbors, and its MineCount is the number's mines not yet flagged.
+// This is the full constraint set behind the solver's deductions, for plugging the board into an external CSP solver.
+func BuildConstraints(b *Board) []Constraint {
+	var cs []Constraint
+	b.ForEachCell(func(x, y int, cell Cell) {
+		if !cell.Revealed || cell.IsMine || cell.AdjMines == 0 {
+			return
+		}
+		var cells [][2]int
+		for _, n := range b.neighbors(x, y) {
+			if c := b.Cells[n[1]][n[0]]; !c.Revealed && !c.Flagged {
+				cells = append(cells, n)
+			}
+		}
+		if len(cells) == 0 {
+			return
+		}
+		slices.SortFunc(cells, compareCells)
+		cs = append(cs, Constraint{Cells: cells, MineCount: cell.AdjMines - b.AdjacentFlaggedCount(x, y)})
+	})
+	return cs
+}
+
+// ReduceConstraints simplifies a constraint set by subset elimination: whenever the cells of A are a strict subset of the cells of B,
+// B is replaced by B's other cells with MineCount B - A. This repeats until no constraint contains another.
+// Duplicates and constraints left without cells are dropped. The input is not modified.
+func ReduceConstraints(cs []Constraint) []Constraint {
+	reduced := make([]Constraint, 0, len(cs))
+	for _, c := range cs {
+		cells := slices.Clone(c.Cells)
+		slices.SortFunc(cells, compareCells)
+		reduced = append(reduced, Constraint{Cells: cells, MineCount: c.MineCount})
+	}
+	reduced = dedupeConstraints(reduced)
+
+	for changed := true; changed; {
+		changed = false
+		for i := range reduced {
+			for j := range reduced {
+				a, b := reduced[i], reduced[j]
+				if len(a.Cells) >= len(b.Cells) || !isSubset(a.Cells, b.Cells) {
+					continue
+				}
+				reduced[j] = Constraint{
+					Cells:     slices.DeleteFunc(slices.Clone(b.Cells), func(c [2]int) bool { return slices.Contains(a.Cells, c) }),
+					MineCount: b.MineCount - a.MineCount,
+				}
+				changed = true
+			}
+		}
+		reduced = dedupeConstraints(reduced)
+	}
+	return reduced
+}
+
+// dedupeConstraints drops repeated and empty constraints, keeping the first of each.
+func dedupeConstraints(cs []Constraint) []Constraint {
+	seen := make(map[string]bool)
+	var result []Constraint
+	for _, c := range cs {
+		key := fmt.Sprint(c.Cells, c.MineCount)
+		if len(c.Cells) == 0 || seen[key] {
+			continue
+		}
+		seen[key] = true
+		result = append(result, c)
+	}
+	return result
+}
+
+// isSubset checks if every cell of a is in b.
+func isSubset(a, b [][2]int) bool {
+	for _, c := range a {
+		if !slices.Contains(b, c) {
+			return false
+		}
+	}
+	return true
+}
+
+// compareCells orders cells row-major, for sorting.
+func compareCells(a, b [2]int) int {
+	if a[1] != b[1] {
+		return a[1] - b[1]
+	}
+	return a[0] - b[0]
+}
+
+// IsFullyConstrained checks if every unrevealed, unflagged cell is part of at least one constraint from BuildConstraints,
+// so no hidden cell is free floating. A fresh board is not, and neither is a board with unflagged cells away from every revealed number.
+// A board with no such cells left is trivially fully constrained.
+func (b *Board) IsFullyConstrained() bool {
+	covered := make(map[[2]int]bool)
+	for _, c := range BuildConstraints(b) {
+		for _, cell := range c.Cells {
+			covered[cell] = true
+		}
+	}
+	free := b.CountCellsWhere(func(x, y int, cell Cell) bool {
+		return !cell.Revealed && !cell.Flagged && !covered[[2]int{x, y}]
+	})
+	return free == 0
+}
+
+// SolvableRegion returns the unrevealed, unflagged cells whose status follows from what is revealed, in row-major order.
+// The constraints from BuildConstraints are reduced with ReduceConstraints, and every constraint with no mines left marks its cells safe,
+// while one with as many mines as cells marks them as mines. Known cells are fed back in until nothing new is found,
+// so this covers SafeCells and forced mines as well as what subset reasoning adds. Flags are trusted, like in SafeCells.
+func (b *Board) SolvableRegion() [][2]int {
+	ghost := b.Clone()
+	safe := make(map[[2]int]bool)
+	for found := true; found; {
+		found = false
+		var cs []Constraint
+		for _, c := range BuildConstraints(ghost) {
+			c.Cells = slices.DeleteFunc(c.Cells, func(cell [2]int) bool { return safe[cell] })
+			cs = append(cs, c)
+		}
+		for _, c := range ReduceConstraints(cs) {
+			if c.MineCount != 0 && c.MineCount != len(c.Cells) {
+				continue
+			}
+			for _, cell := range c.Cells {
+				if c.MineCount == 0 {
+					safe[cell] = true
+				} else {
+					// Flagging the known mines takes them out of the constraints next time round
+					ghost.Cells[cell[1]][cell[0]].Flagged = true
+				}
+				found = true
+			}
+		}
+	}
+	return b.FilterCells(func(x, y int, cell Cell) bool {
+		return !cell.Revealed && !cell.Flagged && (safe[[2]int{x, y}] || ghost.Cells[y][x].Flagged)
+	})
+}
@@ -0,0 +1,174 @@
+package main
+
+import (
+	"slices"
+	"testing"
+)
+
+// equalConstraints compares two constraint sets in order.
+func equalConstraints(a, b []Constraint) bool {
+	return slices.EqualFunc(a, b, func(x, y Constraint) bool {
+		return x.MineCount == y.MineCount && slices.Equal(x.Cells, y.Cells)
+	})
+}
+
+func TestBuildConstraints(t *testing.T) {
+	tests := []struct {
+		name   string
+		reveal [][2]int
+		flag   [][2]int
+		want   []Constraint
+	}{
+		{"fresh board", nil, nil, nil},
+		{"one number", [][2]int{{1, 0}}, nil, []Constraint{
+			{[][2]int{{0, 0}, {2, 0}, {0, 1}, {1, 1}, {2, 1}}, 2},
+		}},
+		{"two numbers", [][2]int{{1, 0}, {0, 1}}, nil, []Constraint{
+			{[][2]int{{0, 0}, {2, 0}, {1, 1}, {2, 1}}, 2},
+			{[][2]int{{0, 0}, {1, 1}}, 1},
+		}},
+		{"flags lower the count", [][2]int{{1, 0}, {0, 1}}, [][2]int{{0, 0}}, []Constraint{
+			{[][2]int{{2, 0}, {1, 1}, {2, 1}}, 1},
+			{[][2]int{{1, 1}}, 0},
+		}},
+		{"zeros give no constraint", [][2]int{{4, 0}}, nil, []Constraint{
+			{[][2]int{{2, 0}, {2, 1}}, 1},
+			{[][2]int{{2, 0}, {2, 1}}, 1},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := boardFromTemplate(t, "M.M..\n.....")
+			for _, c := range tt.reveal {
+				b.RevealCell(c[0], c[1])
+			}
+			for _, c := range tt.flag {
+				b.FlagCell(c[0], c[1])
+			}
+			if got := BuildConstraints(b); !equalConstraints(got, tt.want) {
+				t.Errorf("BuildConstraints() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReduceConstraints(t *testing.T) {
+	tests := []struct {
+		name string
+		in   []Constraint
+		want []Constraint
+	}{
+		{"subset", []Constraint{
+			{[][2]int{{0, 0}, {1, 0}}, 1},
+			{[][2]int{{0, 0}, {1, 0}, {2, 0}}, 2},
+		}, []Constraint{
+			{[][2]int{{0, 0}, {1, 0}}, 1},
+			{[][2]int{{2, 0}}, 1},
+		}},
+		{"chain", []Constraint{
+			{[][2]int{{0, 0}}, 1},
+			{[][2]int{{0, 0}, {1, 0}}, 1},
+			{[][2]int{{0, 0}, {1, 0}, {2, 0}}, 2},
+		}, []Constraint{
+			{[][2]int{{0, 0}}, 1},
+			{[][2]int{{1, 0}}, 0},
+			{[][2]int{{2, 0}}, 1},
+		}},
+		{"duplicates", []Constraint{
+			{[][2]int{{2, 0}, {2, 1}}, 1},
+			{[][2]int{{2, 1}, {2, 0}}, 1},
+		}, []Constraint{
+			{[][2]int{{2, 0}, {2, 1}}, 1},
+		}},
+		{"overlap without a subset", []Constraint{
+			{[][2]int{{0, 0}, {1, 0}}, 1},
+			{[][2]int{{1, 0}, {2, 0}}, 1},
+		}, []Constraint{
+			{[][2]int{{0, 0}, {1, 0}}, 1},
+			{[][2]int{{1, 0}, {2, 0}}, 1},
+		}},
+		{"empty", nil, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			in := slices.Clone(tt.in)
+			for i := range in {
+				in[i].Cells = slices.Clone(in[i].Cells)
+			}
+			if got := ReduceConstraints(in); !equalConstraints(got, tt.want) {
+				t.Errorf("ReduceConstraints() = %v, want %v", got, tt.want)
+			}
+			if !equalConstraints(in, tt.in) {
+				t.Errorf("ReduceConstraints modified its input: %v", in)
+			}
+		})
+	}
+}
+
+func TestIsFullyConstrained(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		reveal   [][2]int
+		flag     [][2]int
+		want     bool
+	}{
+		{"fresh board", "M.M..\n.....", nil, nil, false},
+		{"cells away from every number", "M.M..\n.....", [][2]int{{4, 0}}, nil, false},
+		{"late game", "M.M..\n.....", [][2]int{{4, 0}, {1, 1}}, nil, true},
+		{"flagged cells don't need a constraint", "M.M..\n.....", [][2]int{{1, 0}, {4, 0}}, [][2]int{{0, 0}}, true},
+		{"a flag doesn't constrain its neighbors", "M.M..\n.....", [][2]int{{4, 0}}, [][2]int{{0, 0}}, false},
+		{"won board", "M.M..\n.....", [][2]int{{4, 0}, {1, 0}, {0, 1}, {1, 1}}, nil, true},
+		{"nothing left hidden", "...\n...", [][2]int{{0, 0}}, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := boardFromTemplate(t, tt.template)
+			for _, c := range tt.reveal {
+				b.RevealCell(c[0], c[1])
+			}
+			for _, c := range tt.flag {
+				b.FlagCell(c[0], c[1])
+			}
+			if got := b.IsFullyConstrained(); got != tt.want {
+				t.Errorf("IsFullyConstrained() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSolvableRegion(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		reveal   [][2]int
+		flag     [][2]int
+		want     [][2]int
+	}{
+		{"fresh board", "M.M..\n.....", nil, nil, nil},
+		{"fifty-fifty", "M.M..\n.....", [][2]int{{4, 0}}, nil, nil},
+		{"flags account for every mine", "M.M..\n.....", [][2]int{{1, 0}}, [][2]int{{0, 0}, {2, 0}}, [][2]int{{0, 1}, {1, 1}, {2, 1}}},
+		{"subset reasoning", "...\n.M.\n...\n...", [][2]int{{0, 3}}, nil, [][2]int{{0, 1}, {1, 1}, {2, 1}}},
+		{"1-2-1 pattern", ".M.M.\n.....\n.....", [][2]int{{0, 2}}, nil, [][2]int{{0, 0}, {1, 0}, {2, 0}, {3, 0}, {4, 0}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := boardFromTemplate(t, tt.template)
+			for _, c := range tt.reveal {
+				b.RevealCell(c[0], c[1])
+			}
+			for _, c := range tt.flag {
+				b.FlagCell(c[0], c[1])
+			}
+			got := b.SolvableRegion()
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("SolvableRegion() = %v, want %v", got, tt.want)
+			}
+			for _, c := range b.SafeCells() {
+				if !slices.Contains(got, c) {
+					t.Errorf("safe cell %v is missing from SolvableRegion() = %v", c, got)
+				}
+			}
+		})
+	}
+}
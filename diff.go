@@ -0,0 +1,34 @@
+package main
+
+// BoardDiff struct lists the cells whose state changed between two boards
+type BoardDiff struct {
+	NewlyRevealed   [][2]int
+	NewlyFlagged    [][2]int
+	NewlyUnflagged  [][2]int
+	NewlyQuestioned [][2]int
+}
+
+// DiffBoards compares two states of the same board and returns the cells that changed from before to after.
+// Both boards must have the same dimensions, typically before is a Clone taken before the move was applied.
+func DiffBoards(before, after *Board) BoardDiff {
+	var diff BoardDiff
+	for y := range before.Cells {
+		for x := range before.Cells[y] {
+			was, now := before.Cells[y][x], after.Cells[y][x]
+			coord := [2]int{x, y}
+			if now.Revealed && !was.Revealed {
+				diff.NewlyRevealed = append(diff.NewlyRevealed, coord)
+			}
+			if now.Flagged && !was.Flagged {
+				diff.NewlyFlagged = append(diff.NewlyFlagged, coord)
+			}
+			if !now.Flagged && was.Flagged {
+				diff.NewlyUnflagged = append(diff.NewlyUnflagged, coord)
+			}
+			if now.Questioned && !was.Questioned {
+				diff.NewlyQuestioned = append(diff.NewlyQuestioned, coord)
+			}
+		}
+	}
+	return diff
+}
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestDiffBoards(t *testing.T) {
+	b := boardFromTemplate(t, `
+M...
+....
+...M
+`)
+	b.FlagCell(0, 1)
+	b.QuestionCell(3, 2)
+	before := b.Clone()
+
+	b.RevealCell(2, 0)
+	b.FlagCell(0, 0)
+	b.FlagCell(0, 1)
+	b.QuestionCell(0, 2)
+
+	diff := DiffBoards(before, b)
+	tests := []struct {
+		name string
+		got  [][2]int
+		want [][2]int
+	}{
+		{"NewlyRevealed", diff.NewlyRevealed, [][2]int{{1, 0}, {2, 0}, {3, 0}, {1, 1}, {2, 1}, {3, 1}}},
+		{"NewlyFlagged", diff.NewlyFlagged, [][2]int{{0, 0}}},
+		{"NewlyUnflagged", diff.NewlyUnflagged, [][2]int{{0, 1}}},
+		{"NewlyQuestioned", diff.NewlyQuestioned, [][2]int{{0, 2}}},
+	}
+	for _, tt := range tests {
+		if !slices.Equal(tt.got, tt.want) {
+			t.Errorf("%s = %v, want %v", tt.name, tt.got, tt.want)
+		}
+	}
+}
+
+func TestDiffBoardsUnchanged(t *testing.T) {
+	b := boardFromTemplate(t, "M.\n..")
+	b.RevealCell(1, 1)
+	if diff := DiffBoards(b.Clone(), b); diff.NewlyRevealed != nil || diff.NewlyFlagged != nil || diff.NewlyUnflagged != nil || diff.NewlyQuestioned != nil {
+		t.Errorf("DiffBoards of identical boards = %+v, want empty", diff)
+	}
+}
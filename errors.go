@@ -0,0 +1,50 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Sentinel errors returned by board methods, compare against them with errors.Is
+var (
+	ErrNoCellsRevealed        = errors.New("no cells revealed")
+	ErrInvalidTemplate        = errors.New("invalid board template")
+	ErrOutOfBounds            = errors.New("coordinates out of bounds")
+	ErrAlreadyRevealed        = errors.New("cell already revealed")
+	ErrInvalidCSV             = errors.New("invalid board CSV")
+	ErrNoMines                = errors.New("board has no mines")
+	ErrInvalidSave            = errors.New("invalid save file")
+	ErrInvalidBinary          = errors.New("invalid binary board")
+	ErrInvalidDensity         = errors.New("mine density must be between 0 and 1")
+	ErrNoGuessNotFound        = errors.New("no board solvable without guessing found")
+	ErrInvalidSize            = errors.New("board size must be positive")
+	ErrWouldLoseRevealedCells = errors.New("resize would remove revealed cells")
+	ErrCannotSatisfy          = errors.New("adjacency count cannot be satisfied")
+	ErrInvalidCompact         = errors.New("invalid compact board")
+)
+
+// MinesweeperError struct records the operation and cell behind an error
+// Err is usually one of the sentinel errors above, so callers can still use errors.Is, or errors.As to get at the details.
+type MinesweeperError struct {
+	Op    string
+	Coord *Point
+	Err   error
+}
+
+// Error formats the error as "Op (x, y): Err", leaving out the coordinates if there are none.
+func (e *MinesweeperError) Error() string {
+	if e.Coord == nil {
+		return e.Op + ": " + e.Err.Error()
+	}
+	return fmt.Sprintf("%s (%d, %d): %v", e.Op, e.Coord.X, e.Coord.Y, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *MinesweeperError) Unwrap() error {
+	return e.Err
+}
+
+// cellError wraps err in a MinesweeperError for the cell at (x, y).
+func cellError(op string, x, y int, err error) error {
+	return &MinesweeperError{Op: op, Coord: &Point{X: x, Y: y}, Err: err}
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestMinesweeperErrorAs(t *testing.T) {
+	tests := []struct {
+		name   string
+		call   func(b *Board) error
+		op     string
+		coord  Point
+		target error
+	}{
+		{"flag off the board", func(b *Board) error { return b.PlaceFlag(5, 0) }, "PlaceFlag", Point{5, 0}, ErrOutOfBounds},
+		{"flag a revealed cell", func(b *Board) error { return b.PlaceFlag(2, 0) }, "PlaceFlag", Point{2, 0}, ErrAlreadyRevealed},
+		{"unflag a revealed cell", func(b *Board) error { return b.RemoveFlag(2, 0) }, "RemoveFlag", Point{2, 0}, ErrAlreadyRevealed},
+		{"unsatisfiable count", func(b *Board) error { return b.GuaranteeAdjMines(0, 0, 4) }, "GuaranteeAdjMines", Point{0, 0}, ErrCannotSatisfy},
+		{"move off the board", func(b *Board) error { return b.ApplyMoves([]Move{{Cmd: CmdReveal, X: -1, Y: 1}}) }, "ApplyMoves", Point{-1, 1}, ErrOutOfBounds},
+		{"region off the board", func(b *Board) error { _, err := b.MineCountInRegion(1, 1, 3, 3); return err }, "MineCountInRegion", Point{1, 1}, ErrOutOfBounds},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := boardFromTemplate(t, "M..\n...")
+			b.RevealCell(2, 0)
+			err := tt.call(b)
+
+			var mErr *MinesweeperError
+			if !errors.As(err, &mErr) {
+				t.Fatalf("error %v is not a *MinesweeperError", err)
+			}
+			if mErr.Op != tt.op {
+				t.Errorf("Op = %q, want %q", mErr.Op, tt.op)
+			}
+			if mErr.Coord == nil || *mErr.Coord != tt.coord {
+				t.Errorf("Coord = %v, want %v", mErr.Coord, tt.coord)
+			}
+			if mErr.Err != tt.target || !errors.Is(err, tt.target) {
+				t.Errorf("Err = %v, want %v", mErr.Err, tt.target)
+			}
+		})
+	}
+}
+
+func TestMinesweeperErrorString(t *testing.T) {
+	tests := []struct {
+		name string
+		err  *MinesweeperError
+		want string
+	}{
+		{"with a cell", &MinesweeperError{Op: "RevealCell", Coord: &Point{X: 3, Y: 4}, Err: ErrOutOfBounds}, "RevealCell (3, 4): coordinates out of bounds"},
+		{"without a cell", &MinesweeperError{Op: "Load", Err: ErrInvalidSave}, "Load: invalid save file"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.err.Error(); got != tt.want {
+				t.Errorf("Error() = %q, want %q", got, tt.want)
+			}
+			if !errors.Is(tt.err, tt.err.Err) {
+				t.Errorf("errors.Is(%v, %v) = false", tt.err, tt.err.Err)
+			}
+		})
+	}
+}
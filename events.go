@@ -0,0 +1,78 @@
+package main
+
+// Event interface is implemented by everything a Game notifies its subscribers about
+type Event interface {
+	EventType() string
+}
+
+// CellRevealedEvent is fired for every cell a reveal uncovers, including the cells opened by a flood fill
+type CellRevealedEvent struct {
+	X, Y int
+	Cell Cell
+}
+
+// CellFlaggedEvent is fired when a flag is placed on a cell
+type CellFlaggedEvent struct {
+	X, Y int
+}
+
+// MineHitEvent is fired when the player reveals a mine
+type MineHitEvent struct {
+	X, Y int
+}
+
+// GameWonEvent is fired when the last safe cell is revealed
+type GameWonEvent struct {
+	Metrics
+}
+
+// MoveEvent is fired by Play after every move, with what the move did
+type MoveEvent struct {
+	Move          Move
+	HitMine       bool
+	NewlyRevealed int
+}
+
+// GameEndedEvent is fired once by Finish, whether the game was won, lost or abandoned
+type GameEndedEvent struct {
+	State   GameState
+	Metrics Metrics
+}
+
+func (CellRevealedEvent) EventType() string { return "cellRevealed" }
+func (CellFlaggedEvent) EventType() string  { return "cellFlagged" }
+func (MineHitEvent) EventType() string      { return "mineHit" }
+func (GameWonEvent) EventType() string      { return "gameWon" }
+func (MoveEvent) EventType() string         { return "move" }
+func (GameEndedEvent) EventType() string    { return "gameEnded" }
+
+// listener pairs a subscriber with the id Subscribe handed out for it
+type listener struct {
+	id int
+	fn func(Event)
+}
+
+// Subscribe registers fn to be called for every event the game fires, in the order they happen.
+// It returns an id that can be passed to Unsubscribe.
+func (g *Game) Subscribe(fn func(Event)) int {
+	g.nextListenerID++
+	g.listeners = append(g.listeners, listener{id: g.nextListenerID, fn: fn})
+	return g.nextListenerID
+}
+
+// Unsubscribe removes the listener with the given id. Unknown ids are ignored.
+func (g *Game) Unsubscribe(id int) {
+	for i, l := range g.listeners {
+		if l.id == id {
+			g.listeners = append(g.listeners[:i], g.listeners[i+1:]...)
+			return
+		}
+	}
+}
+
+// emit calls every listener with the event.
+func (g *Game) emit(e Event) {
+	for _, l := range g.listeners {
+		l.fn(e)
+	}
+}
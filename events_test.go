@@ -0,0 +1,102 @@
+package main
+
+import (
+	"slices"
+	"testing"
+)
+
+// recordEvents subscribes to the game and returns a pointer to the events it fires.
+func recordEvents(g *Game) *[]Event {
+	var events []Event
+	g.Subscribe(func(e Event) { events = append(events, e) })
+	return &events
+}
+
+func TestEventsCompleteGame(t *testing.T) {
+	tests := []struct {
+		name  string
+		moves []Move
+		want  []Event
+	}{
+		{"won", []Move{
+			{Cmd: CmdFlag, X: 0, Y: 0},
+			{Cmd: CmdReveal, X: 2, Y: 1},
+			{Cmd: CmdReveal, X: 0, Y: 1},
+		}, []Event{
+			CellFlaggedEvent{X: 0, Y: 0},
+			MoveEvent{Move: Move{Cmd: CmdFlag, X: 0, Y: 0}},
+			CellRevealedEvent{X: 1, Y: 0, Cell: Cell{AdjMines: 1, Revealed: true}},
+			CellRevealedEvent{X: 2, Y: 0, Cell: Cell{Revealed: true}},
+			CellRevealedEvent{X: 1, Y: 1, Cell: Cell{AdjMines: 1, Revealed: true}},
+			CellRevealedEvent{X: 2, Y: 1, Cell: Cell{Revealed: true}},
+			MoveEvent{Move: Move{Cmd: CmdReveal, X: 2, Y: 1}, NewlyRevealed: 4},
+			CellRevealedEvent{X: 0, Y: 1, Cell: Cell{AdjMines: 1, Revealed: true}},
+			GameWonEvent{},
+			MoveEvent{Move: Move{Cmd: CmdReveal, X: 0, Y: 1}, NewlyRevealed: 1},
+		}},
+		{"lost", []Move{
+			{Cmd: CmdReveal, X: 0, Y: 0},
+		}, []Event{
+			CellRevealedEvent{X: 0, Y: 0, Cell: Cell{IsMine: true, Revealed: true}},
+			MineHitEvent{X: 0, Y: 0},
+			MoveEvent{Move: Move{Cmd: CmdReveal, X: 0, Y: 0}, HitMine: true, NewlyRevealed: 1},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewGame(boardFromTemplate(t, "M..\n..."))
+			events := recordEvents(g)
+			for _, m := range tt.moves {
+				if err := g.Play(m); err != nil {
+					t.Fatal(err)
+				}
+			}
+			got := *events
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %d events, want %d:\n%v", len(got), len(tt.want), got)
+			}
+			for i, want := range tt.want {
+				if won, ok := got[i].(GameWonEvent); ok {
+					// The metrics include the duration, only check the event type and the outcome
+					if _, ok := want.(GameWonEvent); !ok || !won.Won {
+						t.Errorf("event %d = %+v, want %+v", i, got[i], want)
+					}
+					continue
+				}
+				if got[i] != want {
+					t.Errorf("event %d = %+v, want %+v", i, got[i], want)
+				}
+			}
+		})
+	}
+}
+
+func TestUnsubscribe(t *testing.T) {
+	g := NewGame(boardFromTemplate(t, "M..\n..."))
+	var first, second []string
+	id := g.Subscribe(func(e Event) { first = append(first, e.EventType()) })
+	g.Subscribe(func(e Event) { second = append(second, e.EventType()) })
+
+	g.Play(Move{Cmd: CmdFlag, X: 0, Y: 0})
+	g.Unsubscribe(id)
+	g.Unsubscribe(id + 100)
+	g.Play(Move{Cmd: CmdFlag, X: 0, Y: 0})
+
+	if want := []string{"cellFlagged", "move"}; !slices.Equal(first, want) {
+		t.Errorf("unsubscribed listener got %v, want %v", first, want)
+	}
+	if want := []string{"cellFlagged", "move", "move"}; !slices.Equal(second, want) {
+		t.Errorf("remaining listener got %v, want %v", second, want)
+	}
+}
+
+func TestPlayUnknownCommand(t *testing.T) {
+	g := NewGame(boardFromTemplate(t, "M."))
+	events := recordEvents(g)
+	if err := g.Play(Move{Cmd: "dig"}); err == nil {
+		t.Error("Play with an unknown command returned no error")
+	}
+	if len(*events) != 0 {
+		t.Errorf("unknown command fired %v", *events)
+	}
+}
@@ -0,0 +1,375 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Game struct wraps a board with the bookkeeping needed to report on a finished game
+type Game struct {
+	Board *Board
+	// The game loop has no undo yet, so UndoCount stays at zero
+	UndoCount int
+	// HintsUsed counts the moves the solver played for the player with the step command
+	HintsUsed int
+	// Challenge limits the time for each move, nil means no limit
+	Challenge *ChallengeTimer
+
+	peakUncovered  float64
+	finished       bool
+	listeners      []listener
+	nextListenerID int
+}
+
+// Metrics struct aggregates the performance statistics of a game
+type Metrics struct {
+	Duration                   time.Duration `json:"duration"`
+	MoveCount                  int           `json:"moveCount"`
+	ThreeBV                    int           `json:"threeBV"`
+	ThreeBVCleared             int           `json:"threeBVCleared"`
+	Won                        bool          `json:"won"`
+	Efficiency                 float64       `json:"efficiency"`
+	FlagsCorrect               int           `json:"flagsCorrect"`
+	FlagsIncorrect             int           `json:"flagsIncorrect"`
+	UndoCount                  int           `json:"undoCount"`
+	HintsUsed                  int           `json:"hintsUsed"`
+	PeakBoardUncoveredFraction float64       `json:"peakBoardUncoveredFraction"`
+}
+
+// NewGame creates a new game for the given board.
+func NewGame(board *Board) *Game {
+	return &Game{Board: board}
+}
+
+// Play applies a move to the board and fires a MoveEvent with the result. It returns an error if the command is unknown.
+// The game is over once Board.State is no longer StatePlaying.
+func (g *Game) Play(m Move) error {
+	result := MoveEvent{Move: m}
+	revealed := g.Board.CountRevealed()
+	switch m.Cmd {
+	case CmdReveal:
+		result.HitMine = g.Reveal(m.X, m.Y)
+	case CmdFlag:
+		g.Flag(m.X, m.Y)
+	case CmdQuestion:
+		g.Question(m.X, m.Y)
+	case CmdSweep:
+		result.HitMine = g.Sweep(m.X, m.Y)
+	case CmdCross:
+		result.HitMine = g.Cross(m.X, m.Y)
+	default:
+		return errors.New("Invalid command. Please use 'reveal', 'flag', 'question', 'sweep' or 'cross'.")
+	}
+	result.NewlyRevealed = g.Board.CountRevealed() - revealed
+	g.emit(result)
+	return nil
+}
+
+// SolverStep plays the next solver deduction with Board.QuickSolveStep and returns the move it made, or found = false if there is none.
+// Subscribers get the same events as if the move had been passed to Play.
+func (g *Game) SolverStep() (m Move, found bool) {
+	revealed := g.Board.CountRevealed()
+	hitMine := g.reveal(func() bool {
+		action, coord, ok := g.Board.QuickSolveStep()
+		m, found = Move{Cmd: action, X: coord.X, Y: coord.Y}, ok
+		return ok && g.Board.State == StateLost
+	})
+	if !found {
+		return Move{}, false
+	}
+	if m.Cmd == CmdFlag && g.Board.Cells[m.Y][m.X].Flagged {
+		g.emit(CellFlaggedEvent{X: m.X, Y: m.Y})
+	}
+	g.emit(MoveEvent{Move: m, HitMine: hitMine, NewlyRevealed: g.Board.CountRevealed() - revealed})
+	return m, true
+}
+
+// Reveal reveals a cell, which the board counts as a move if it opened anything. It returns true if a mine was hit.
+// Subscribers get a CellRevealedEvent for every uncovered cell, followed by a MineHitEvent or GameWonEvent if the move ended the game.
+func (g *Game) Reveal(x, y int) bool {
+	return g.reveal(func() bool { return g.Board.RevealCell(x, y) })
+}
+
+// Sweep reveals the four diagonals through a cell with DiagonalReveal, counted as one move like Reveal. It returns true if a mine was hit.
+// Subscribers get the same events as for Reveal.
+func (g *Game) Sweep(x, y int) bool {
+	return g.reveal(func() bool { return g.Board.DiagonalReveal(x, y) })
+}
+
+// Cross reveals the row and column through a cell with CrossReveal, counted as one move like Reveal. It returns true if a mine was hit.
+// Subscribers get the same events as for Reveal.
+func (g *Game) Cross(x, y int) bool {
+	return g.reveal(func() bool { return g.Board.CrossReveal(x, y) })
+}
+
+// reveal records a move that reveals cells and fires the events for it. It returns true if a mine was hit.
+func (g *Game) reveal(move func() bool) bool {
+	before := g.Board.Clone()
+	hitMine := move()
+	if f := g.Board.UncoveredFraction(); f > g.peakUncovered {
+		g.peakUncovered = f
+	}
+
+	var mine [2]int
+	for _, c := range DiffBoards(before, g.Board).NewlyRevealed {
+		cell := g.Board.Cells[c[1]][c[0]]
+		if cell.IsMine {
+			mine = c
+		}
+		g.emit(CellRevealedEvent{X: c[0], Y: c[1], Cell: cell})
+	}
+	if hitMine {
+		g.emit(MineHitEvent{X: mine[0], Y: mine[1]})
+	} else if before.State == StatePlaying && g.Board.State == StateWon {
+		g.emit(GameWonEvent{Metrics: g.EndMetrics()})
+	}
+	return hitMine
+}
+
+// Flag toggles the flag on a cell, placing a flag counts as a move. Subscribers get a CellFlaggedEvent if a flag was placed.
+func (g *Game) Flag(x, y int) {
+	g.Board.FlagCell(x, y)
+	if g.Board.isValidCell(x, y) && g.Board.Cells[y][x].Flagged {
+		g.emit(CellFlaggedEvent{X: x, Y: y})
+	}
+}
+
+// Question toggles the question mark on a cell. Question marks are notes to self and don't count as moves.
+func (g *Game) Question(x, y int) {
+	g.Board.QuestionCell(x, y)
+}
+
+// Finish stops the game timer and fires a GameEndedEvent. Calling it more than once has no further effect.
+func (g *Game) Finish() {
+	if g.finished {
+		return
+	}
+	g.finished = true
+	if g.Board.EndTime.IsZero() {
+		g.Board.EndTime = g.Board.clock()
+	}
+	g.emit(GameEndedEvent{State: g.Board.State, Metrics: g.EndMetrics()})
+}
+
+// EndMetrics computes the metrics for the game.
+// MoveCount is the board's reveal and flag moves, see Board.MoveCount and Board.FlagMoveCount.
+// Efficiency is the 3BV actually cleared divided by the number of moves, so unneeded clicks and flags bring it down
+// and a game lost early doesn't get credit for the part of the board it never opened.
+func (g *Game) EndMetrics() Metrics {
+	m := Metrics{
+		Duration:                   g.Board.Elapsed(),
+		MoveCount:                  g.Board.MoveCount() + g.Board.FlagMoveCount(),
+		ThreeBV:                    g.Board.Compute3BV(),
+		Won:                        g.Board.State == StateWon,
+		UndoCount:                  g.UndoCount,
+		HintsUsed:                  g.HintsUsed,
+		PeakBoardUncoveredFraction: g.peakUncovered,
+	}
+	m.ThreeBVCleared = m.ThreeBV - g.Board.MinSolvingMoves()
+	if m.MoveCount > 0 {
+		m.Efficiency = float64(m.ThreeBVCleared) / float64(m.MoveCount)
+	}
+	g.Board.ForEachCell(func(x, y int, cell Cell) {
+		if !cell.Flagged {
+			return
+		}
+		if cell.IsMine {
+			m.FlagsCorrect++
+		} else {
+			m.FlagsIncorrect++
+		}
+	})
+	return m
+}
+
+// Print writes the metrics as a table, one statistic per line.
+func (m Metrics) Print(w io.Writer) {
+	fmt.Fprintln(w, "+-------------------+------------+")
+	fmt.Fprintf(w, "| %-17s | %10s |\n", "Duration", fmt.Sprintf("%.2fs", m.Duration.Seconds()))
+	fmt.Fprintf(w, "| %-17s | %10d |\n", "Moves", m.MoveCount)
+	fmt.Fprintf(w, "| %-17s | %10d |\n", "3BV", m.ThreeBV)
+	fmt.Fprintf(w, "| %-17s | %10d |\n", "3BV cleared", m.ThreeBVCleared)
+	fmt.Fprintf(w, "| %-17s | %10.2f |\n", "Efficiency", m.Efficiency)
+	fmt.Fprintf(w, "| %-17s | %10d |\n", "Correct flags", m.FlagsCorrect)
+	fmt.Fprintf(w, "| %-17s | %10d |\n", "Incorrect flags", m.FlagsIncorrect)
+	fmt.Fprintf(w, "| %-17s | %10d |\n", "Undos", m.UndoCount)
+	fmt.Fprintf(w, "| %-17s | %10d |\n", "Hints used", m.HintsUsed)
+	fmt.Fprintf(w, "| %-17s | %9.0f%% |\n", "Peak uncovered", m.PeakBoardUncoveredFraction*100)
+	fmt.Fprintln(w, "+-------------------+------------+")
+}
+
+// errEmptyInput is returned by parseMove for a blank line, which callers can simply skip
+var errEmptyInput = errors.New("empty input")
+
+// parseMove parses a line of user input in the format 'cmd x y' into a Move.
+// The user types 1-based coordinates, the returned Move holds 0-based ones. 'quit' is accepted on its own.
+// The coordinates are not checked against the board, that's up to the caller.
+func parseMove(input string) (Move, error) {
+	parts := strings.Fields(input) // Split input based on whitespace
+	if len(parts) == 0 {
+		return Move{}, errEmptyInput
+	}
+
+	// Extract the command and coordinates
+	cmd := parts[0]
+	if cmd == CmdQuit {
+		return Move{Cmd: CmdQuit}, nil
+	}
+
+	// Ensure we have the correct number of arguments
+	if len(parts) != 3 {
+		return Move{}, errors.New("Invalid input. Please enter a command followed by two integers.")
+	}
+
+	// Check errX and errY separately immediately after conversion
+	// Atoi is equivalent to ParseInt(s, 10, 0), converted to type int.
+	// The bitSize argument specifies the integer type that the result must fit into.
+	// In Go, it is idiomatic to check the error as soon as possible, and in this case we should check each conversion separately as strconv.Atoi() is a function call that can return an error.
+	x, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return Move{}, errors.New("Invalid x coordinate. Please enter an integer.")
+	}
+	y, err := strconv.Atoi(parts[2])
+	if err != nil {
+		return Move{}, errors.New("Invalid y coordinate. Please enter an integer.")
+	}
+
+	// Assuming user input is a 1-based index as this is a bit more intuitive for the user
+	// We convert the input to a 0-based index to match the array
+	return Move{Cmd: cmd, X: x - 1, Y: y - 1}, nil
+}
+
+// handleInput parses and plays one line of user input, printing any problem with it. It returns true if the player quit.
+func (g *Game) handleInput(input string) bool {
+	if strings.TrimSpace(input) == CmdStep {
+		// A solver step fires the same events as any other move, so subscribers and the log see it, and it also counts as a hint
+		move, found := g.SolverStep()
+		if !found {
+			fmt.Println("No safe deduction left, you'll have to guess.")
+			return false
+		}
+		g.HintsUsed++
+		fmt.Printf("Solver: %s (%d, %d)\n", move.Cmd, move.X+1, move.Y+1)
+		if g.Challenge != nil {
+			g.Challenge.MoveMade()
+		}
+		return false
+	}
+	if parts := strings.Fields(input); len(parts) == 2 && parts[0] == CmdSave {
+		if err := g.Board.SaveFile(parts[1]); err != nil {
+			fmt.Println("Could not save the game:", err)
+		} else {
+			fmt.Println("Game saved to", parts[1])
+		}
+		return false
+	}
+
+	move, err := parseMove(input)
+	// Ensure we have some input
+	if err == errEmptyInput {
+		return false
+	}
+	if err != nil {
+		fmt.Println(err)
+		return false
+	}
+
+	if move.Cmd == CmdQuit {
+		return true
+	}
+
+	if !g.Board.isValidCell(move.X, move.Y) {
+		fmt.Println("Invalid coordinates. Please try again.")
+		return false
+	}
+
+	if err := g.Play(move); err != nil {
+		fmt.Println(err)
+		return false
+	}
+	if g.Challenge != nil {
+		g.Challenge.MoveMade()
+	}
+	return false
+}
+
+// Run plays the game on the console, reading commands from in until the game ends.
+// If the game has a Challenge timer, a random cell is revealed whenever the player runs out of time for a move.
+func (g *Game) Run(in io.Reader) {
+	board := g.Board
+
+	// Game loop
+	// Read user input via the console and execute commands
+	// Initially, I used fmt.Scan to read user input, but this method was blocking and doesn't allow for easy exit. It also was less robust for handling inputs. I switched to bufio.Scanner to allow for non-blocking input and added a quit command to exit the game.
+	// Lines are read on their own goroutine so the challenge timer can interrupt the wait for input.
+	lines := make(chan string)
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		defer close(lines)
+		scanner := bufio.NewScanner(in)
+		for scanner.Scan() {
+			select {
+			case lines <- scanner.Text():
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	for {
+		board.PrintBoard(false)
+		fmt.Println("Safety:", board.SafetyRating())
+		fmt.Println("Coordinates are a 1-based index. (1, 1) is the top-left corner.")
+		fmt.Println("Enter your move in the format 'cmd x y' (cmd: reveal, flag, question, sweep, cross), 'step' for a solver hint, 'save file' to save the game, or type 'quit' to exit:")
+
+		var timeout <-chan time.Time
+		if g.Challenge != nil {
+			g.Challenge.Start()
+			fmt.Printf("You have %d seconds to make your move.\n", int(g.Challenge.Remaining().Seconds()))
+			timeout = time.After(g.Challenge.Remaining())
+		}
+
+		select {
+		case input, ok := <-lines:
+			// The time limit is checked before the move is played, a move entered too late doesn't count
+			if ok && board.TimeExpired() {
+				board.endGame(StateLost)
+				board.GameOver(true)
+				fmt.Printf("Time limit of %v exceeded!\n", board.TimeLimit)
+				goto End
+			}
+			// Treat the end of the input like a quit, otherwise we'd spin on an empty line forever
+			if !ok || g.handleInput(input) {
+				board.PrintBoard(true)
+				fmt.Println("Quit game.")
+				goto End
+			}
+		case <-timeout:
+			fmt.Println("Time's up! Revealing a random cell.")
+			g.CheckChallenge()
+		}
+
+		switch board.State {
+		case StateLost:
+			board.PrintBoard(true)
+			fmt.Println("You hit a mine! Game over!")
+			goto End
+		case StateWon:
+			board.PrintBoard(true)
+			fmt.Println("Congratulations, you won!")
+			goto End
+		}
+	}
+
+	// Stop the timer and report how the game went
+End:
+	g.Finish()
+	g.EndMetrics().Print(os.Stdout)
+}
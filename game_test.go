@@ -0,0 +1,226 @@
+package main
+
+import (
+	"bytes"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestHandleInputStepPlaysThroughGame(t *testing.T) {
+	g := NewGame(boardFromTemplate(t, `
+....
+....
+.M..
+M..M
+`))
+	var moves, flags, reveals int
+	g.Subscribe(func(e Event) {
+		switch e.(type) {
+		case MoveEvent:
+			moves++
+		case CellFlaggedEvent:
+			flags++
+		case CellRevealedEvent:
+			reveals++
+		}
+	})
+	var log bytes.Buffer
+	logger := NewGameLogger(g, &log)
+
+	g.handleInput("reveal 4 1")
+	revealed := reveals
+	peak := g.peakUncovered
+	for _, input := range []string{"step", "step", "step"} {
+		g.handleInput(input)
+	}
+	if err := logger.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	// The first step flags (1,2), the second reveals (0,2), the third finds nothing
+	if moves != 3 {
+		t.Errorf("got %d MoveEvents, want 3", moves)
+	}
+	if flags != 1 {
+		t.Errorf("got %d CellFlaggedEvents, want 1", flags)
+	}
+	if reveals != revealed+1 {
+		t.Errorf("step fired %d CellRevealedEvents, want 1", reveals-revealed)
+	}
+	if g.HintsUsed != 2 {
+		t.Errorf("HintsUsed = %d, want 2", g.HintsUsed)
+	}
+	if g.peakUncovered <= peak {
+		t.Errorf("peak uncovered fraction stayed at %v after a step revealed a cell", g.peakUncovered)
+	}
+	// One start line and one line per move
+	if lines := strings.Count(log.String(), "\n"); lines != 4 {
+		t.Errorf("log has %d lines, want 4:\n%s", lines, log.String())
+	}
+}
+
+func TestSolverStep(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		start    [2]int
+		want     []Move
+	}{
+		{"solves the board", "M...\nM...\n....", [2]int{2, 0}, []Move{
+			{Cmd: CmdFlag, X: 0, Y: 0},
+			{Cmd: CmdFlag, X: 0, Y: 1},
+			{Cmd: CmdReveal, X: 0, Y: 2},
+		}},
+		{"stops at a guess", ".M.\n...\n...", [2]int{0, 2}, nil},
+		{"game over", "M..\n...", [2]int{0, 0}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// The same moves are played through Play on a second game, the events must match
+			g := NewGame(boardFromTemplate(t, tt.template))
+			replay := NewGame(boardFromTemplate(t, tt.template))
+			g.Reveal(tt.start[0], tt.start[1])
+			replay.Reveal(tt.start[0], tt.start[1])
+			events, replayEvents := recordEvents(g), recordEvents(replay)
+
+			var got []Move
+			for {
+				m, found := g.SolverStep()
+				if !found {
+					break
+				}
+				got = append(got, m)
+				replay.Play(m)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("SolverStep played %v, want %v", got, tt.want)
+			}
+			if len(*events) != len(*replayEvents) {
+				t.Fatalf("SolverStep fired %v, Play fired %v", *events, *replayEvents)
+			}
+			for i, e := range *events {
+				if _, won := e.(GameWonEvent); won && e.EventType() == (*replayEvents)[i].EventType() {
+					continue
+				}
+				if e != (*replayEvents)[i] {
+					t.Errorf("event %d = %+v, Play fired %+v", i, e, (*replayEvents)[i])
+				}
+			}
+			if g.Board.State != replay.Board.State {
+				t.Errorf("State = %v, want %v", g.Board.State, replay.Board.State)
+			}
+		})
+	}
+}
+
+func TestEndMetricsMoveCount(t *testing.T) {
+	g := NewGame(boardFromTemplate(t, `
+.....
+.....
+....M
+`))
+	g.Play(Move{Cmd: CmdCross, X: 3, Y: 1})
+	g.Play(Move{Cmd: CmdFlag, X: 4, Y: 2})
+	g.Play(Move{Cmd: CmdQuestion, X: 4, Y: 1})
+	m := g.EndMetrics()
+	if want := g.Board.MoveCount() + g.Board.FlagMoveCount(); m.MoveCount != want || want != 2 {
+		t.Errorf("Metrics.MoveCount = %d, board counted %d, want 2", m.MoveCount, want)
+	}
+}
+
+func TestEndMetrics(t *testing.T) {
+	b := boardFromTemplate(t, `
+M...
+....
+...M
+`)
+	start := b.StartTime
+	b.now = func() time.Time { return start.Add(42 * time.Second) }
+	g := NewGame(b)
+	g.HintsUsed = 1
+	g.UndoCount = 2
+	g.Play(Move{Cmd: CmdFlag, X: 0, Y: 0})
+	g.Play(Move{Cmd: CmdFlag, X: 1, Y: 0})
+	g.Play(Move{Cmd: CmdReveal, X: 0, Y: 2})
+	g.Play(Move{Cmd: CmdReveal, X: 3, Y: 0})
+	if b.State != StateWon {
+		t.Fatalf("State = %v, want StateWon", b.State)
+	}
+
+	want := Metrics{
+		Duration:                   42 * time.Second,
+		MoveCount:                  4,
+		ThreeBV:                    2,
+		ThreeBVCleared:             2,
+		Won:                        true,
+		Efficiency:                 0.5,
+		FlagsCorrect:               1,
+		FlagsIncorrect:             1,
+		UndoCount:                  2,
+		HintsUsed:                  1,
+		PeakBoardUncoveredFraction: 1,
+	}
+	if got := g.EndMetrics(); got != want {
+		t.Errorf("EndMetrics() = %+v\nwant %+v", got, want)
+	}
+}
+
+func TestEndMetricsEfficiencyOnLoss(t *testing.T) {
+	g := NewGame(boardFromTemplate(t, `
+M...
+....
+...M
+`))
+	g.Play(Move{Cmd: CmdReveal, X: 0, Y: 0})
+	m := g.EndMetrics()
+	if m.ThreeBVCleared != 0 || m.Efficiency != 0 || m.Won {
+		t.Errorf("losing on the first click gave %d 3BV cleared, efficiency %v, won %v, want 0, 0, false", m.ThreeBVCleared, m.Efficiency, m.Won)
+	}
+}
+
+func TestMetricsPrint(t *testing.T) {
+	var out bytes.Buffer
+	Metrics{MoveCount: 7, ThreeBV: 5, Efficiency: 0.5}.Print(&out)
+	for _, want := range []string{"| Moves             |          7 |", "| 3BV               |          5 |", "| Efficiency        |       0.50 |"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("table is missing %q:\n%s", want, out.String())
+		}
+	}
+}
+
+func TestRunTimeLimit(t *testing.T) {
+	const input = "flag 1 2\nflag 2 2\nflag 3 2\nflag 4 2\nflag 5 2\nflag 6 2\n"
+	tests := []struct {
+		name  string
+		limit time.Duration
+		flags int
+		state GameState
+	}{
+		{"no limit", 0, 6, StatePlaying},
+		{"crossed after the third move", 25 * time.Second, 3, StateLost},
+		{"reached but not crossed", 30 * time.Second, 4, StateLost},
+		{"crossed after the fourth move", 39 * time.Second, 4, StateLost},
+		{"never reached", time.Minute, 6, StatePlaying},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := boardFromTemplate(t, "M.....\n......")
+			WithTimeLimit(tt.limit)(b)
+			// Every move takes 10 seconds on the board's clock
+			b.now = func() time.Time {
+				return b.StartTime.Add(time.Duration(b.FlagMoveCount()) * 10 * time.Second)
+			}
+			g := NewGame(b)
+			g.Run(strings.NewReader(input))
+
+			if got := b.CountFlags(); got != tt.flags {
+				t.Errorf("%d moves were played, want %d", got, tt.flags)
+			}
+			if b.State != tt.state {
+				t.Errorf("State = %v, want %v", b.State, tt.state)
+			}
+		})
+	}
+}
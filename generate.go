@@ -0,0 +1,44 @@
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"slices"
+	"time"
+)
+
+// maxNoGuessAttempts is how many layouts GenerateNoGuess tries before giving up
+const maxNoGuessAttempts = 10000
+
+// progressInterval is the least time between two progress lines
+const progressInterval = time.Second
+
+// GenerateNoGuess generates boards until it finds one that can be won without guessing, starting from an opening.
+// The returned board has that opening revealed already, so the player knows where to start. The options are applied to every attempt,
+// and WithProgressWriter reports the attempts as they go. It returns ErrNoGuessNotFound after maxNoGuessAttempts failed layouts.
+func GenerateNoGuess(width, height, mines int, opts ...BoardOption) (*Board, error) {
+	var lastReport time.Time
+	for attempt := 1; attempt <= maxNoGuessAttempts; attempt++ {
+		seed := rand.Int63()
+		b := NewBoard(width, height, mines, slices.Concat(opts, []BoardOption{WithSeed(seed)})...)
+		if b.progress != nil && (lastReport.IsZero() || b.clock().Sub(lastReport) >= progressInterval) {
+			lastReport = b.clock()
+			fmt.Fprintf(b.progress, "Attempt %d: testing board (seed=%d)...\n", attempt, seed)
+		}
+		if b.solvableFromOpening() {
+			return b, nil
+		}
+	}
+	return nil, ErrNoGuessNotFound
+}
+
+// solvableFromOpening reveals the board's first opening and checks if WinPath can finish it from there.
+func (b *Board) solvableFromOpening() bool {
+	openings := b.SafeOpeningCells()
+	if len(openings) == 0 {
+		return false
+	}
+	b.RevealCell(openings[0][0], openings[0][1])
+	_, ok := b.WinPath()
+	return ok
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+)
+
+// progressLine is the status line GenerateNoGuess writes to the progress writer
+var progressLine = regexp.MustCompile(`^Attempt (\d+): testing board \(seed=-?\d+\)\.\.\.$`)
+
+func TestGenerateNoGuessProgress(t *testing.T) {
+	tests := []struct {
+		name          string
+		width, height int
+		mines         int
+		step          time.Duration
+		wantLines     int
+		wantErr       error
+	}{
+		// A 2x2 board with a mine has no opening, so every attempt fails
+		{"every attempt at one a second", 2, 2, 1, time.Second, maxNoGuessAttempts, ErrNoGuessNotFound},
+		{"at most one line a second", 2, 2, 1, 400 * time.Millisecond, 3334, ErrNoGuessNotFound},
+		{"fast clock", 2, 2, 1, time.Millisecond, 10, ErrNoGuessNotFound},
+		{"found on the first attempt", 5, 5, 1, time.Second, 1, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Every board GenerateNoGuess tries moves the clock on by step
+			now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+			tick := func(b *Board) {
+				now = now.Add(tt.step)
+				b.now = func() time.Time { return now }
+			}
+			var out bytes.Buffer
+			_, err := GenerateNoGuess(tt.width, tt.height, tt.mines, WithProgressWriter(&out), tick)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("GenerateNoGuess() error = %v, want %v", err, tt.wantErr)
+			}
+
+			lines := strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n")
+			if len(lines) != tt.wantLines {
+				t.Errorf("wrote %d progress lines, want %d", len(lines), tt.wantLines)
+			}
+			for _, line := range lines {
+				if !progressLine.MatchString(line) {
+					t.Fatalf("bad progress line %q", line)
+				}
+			}
+			if !strings.HasPrefix(lines[0], "Attempt 1: ") {
+				t.Errorf("first line = %q, want attempt 1", lines[0])
+			}
+		})
+	}
+}
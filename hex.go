@@ -0,0 +1,118 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"math/rand"
+	"strings"
+)
+
+// HexCell struct is a cell on a hexagonal board, with its axial coordinates
+type HexCell struct {
+	Cell
+	Q, R int
+}
+
+// HexBoard struct represents a hexagon-shaped board of hexagonal cells, each with 6 neighbors instead of 8
+// Cells are addressed with axial coordinates (q, r), where every cell within Radius steps of (0, 0) is on the board.
+type HexBoard struct {
+	Radius     int
+	Cells      map[[2]int]*HexCell
+	TotalMines int
+}
+
+// hexDirections are the axial offsets of the 6 neighbors of a hex cell
+var hexDirections = [6][2]int{{1, 0}, {1, -1}, {0, -1}, {-1, 0}, {-1, 1}, {0, 1}}
+
+// NewHexBoard creates a hexagonal board with the given radius, so a radius of 0 is a single cell, and places the mines randomly.
+// Like NewBoard, the number of mines is capped at 5/9 of the cells.
+func NewHexBoard(radius, mines int) *HexBoard {
+	h := &HexBoard{Radius: radius, Cells: make(map[[2]int]*HexCell)}
+	var positions [][2]int
+	for r := -radius; r <= radius; r++ {
+		for q := max(-radius, -r-radius); q <= min(radius, -r+radius); q++ {
+			h.Cells[[2]int{q, r}] = &HexCell{Q: q, R: r}
+			positions = append(positions, [2]int{q, r})
+		}
+	}
+
+	mines = max(min(mines, len(positions)*5/9), 0)
+	rand.Shuffle(len(positions), func(i, j int) {
+		positions[i], positions[j] = positions[j], positions[i]
+	})
+	for _, p := range positions[:mines] {
+		h.Cells[p].IsMine = true
+	}
+	h.TotalMines = mines
+
+	for _, cell := range h.Cells {
+		for _, n := range h.Neighbors(cell.Q, cell.R) {
+			if h.Cells[n].IsMine {
+				cell.AdjMines++
+			}
+		}
+	}
+	return h
+}
+
+// isValidHexCell checks if the axial coordinates are on the board.
+func (h *HexBoard) isValidHexCell(q, r int) bool {
+	return max(abs(q), abs(r), abs(q+r)) <= h.Radius
+}
+
+// Neighbors returns the axial coordinates of the cells adjacent to (q, r) that are on the board. Interior cells always have 6.
+func (h *HexBoard) Neighbors(q, r int) [][2]int {
+	result := make([][2]int, 0, len(hexDirections))
+	for _, d := range hexDirections {
+		if h.isValidHexCell(q+d[0], r+d[1]) {
+			result = append(result, [2]int{q + d[0], r + d[1]})
+		}
+	}
+	return result
+}
+
+// RevealHexCell reveals a cell, flood filling through cells with no adjacent mines like RevealCell. It returns true if the cell is a mine.
+func (h *HexBoard) RevealHexCell(q, r int) bool {
+	if !h.isValidHexCell(q, r) || h.Cells[[2]int{q, r}].Revealed {
+		return false
+	}
+	h.Cells[[2]int{q, r}].Revealed = true
+	if h.Cells[[2]int{q, r}].IsMine {
+		return true
+	}
+	queue := [][2]int{{q, r}}
+	for len(queue) > 0 {
+		current := h.Cells[queue[0]]
+		queue = queue[1:]
+		if current.AdjMines != 0 {
+			continue
+		}
+		for _, n := range h.Neighbors(current.Q, current.R) {
+			if !h.Cells[n].Revealed {
+				h.Cells[n].Revealed = true
+				queue = append(queue, n)
+			}
+		}
+	}
+	return false
+}
+
+// PrintHexBoard prints the board as a hexagon, one row of r at a time, using the same symbols as PrintBoard.
+// Each row is indented by half a cell per step away from the middle row, so neighboring cells line up diagonally.
+func (h *HexBoard) PrintHexBoard(w io.Writer, showMines bool) {
+	for r := -h.Radius; r <= h.Radius; r++ {
+		fmt.Fprint(w, strings.Repeat(" ", abs(r)))
+		for q := max(-h.Radius, -r-h.Radius); q <= min(h.Radius, -r+h.Radius); q++ {
+			fmt.Fprint(w, h.Cells[[2]int{q, r}].symbol(showMines), " ")
+		}
+		fmt.Fprintln(w)
+	}
+}
+
+// abs returns the absolute value of n.
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
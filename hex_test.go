@@ -0,0 +1,130 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+)
+
+// hexBoardWithMines builds a hex board with mines on exactly the given cells.
+func hexBoardWithMines(radius int, mines ...[2]int) *HexBoard {
+	h := NewHexBoard(radius, 0)
+	for _, m := range mines {
+		h.Cells[m].IsMine = true
+		for _, n := range h.Neighbors(m[0], m[1]) {
+			h.Cells[n].AdjMines++
+		}
+	}
+	h.TotalMines = len(mines)
+	return h
+}
+
+func TestNewHexBoard(t *testing.T) {
+	tests := []struct {
+		radius, mines    int
+		cells, wantMines int
+	}{
+		{0, 0, 1, 0},
+		{1, 2, 7, 2},
+		{2, 5, 19, 5},
+		{3, 10, 37, 10},
+		{2, 100, 19, 10},
+	}
+	for _, tt := range tests {
+		h := NewHexBoard(tt.radius, tt.mines)
+		if len(h.Cells) != tt.cells {
+			t.Errorf("radius %d board has %d cells, want %d", tt.radius, len(h.Cells), tt.cells)
+		}
+		mines := 0
+		for p, cell := range h.Cells {
+			if cell.IsMine {
+				mines++
+			}
+			if cell.Q != p[0] || cell.R != p[1] {
+				t.Errorf("cell at %v says it's at (%d, %d)", p, cell.Q, cell.R)
+			}
+			adj := 0
+			for _, n := range h.Neighbors(cell.Q, cell.R) {
+				if h.Cells[n].IsMine {
+					adj++
+				}
+			}
+			if !cell.IsMine && cell.AdjMines != adj {
+				t.Errorf("cell %v has AdjMines %d, want %d", p, cell.AdjMines, adj)
+			}
+		}
+		if mines != tt.wantMines || h.TotalMines != tt.wantMines {
+			t.Errorf("NewHexBoard(%d, %d) placed %d mines, TotalMines %d, want %d", tt.radius, tt.mines, mines, h.TotalMines, tt.wantMines)
+		}
+	}
+}
+
+func TestHexNeighbors(t *testing.T) {
+	h := NewHexBoard(3, 0)
+	tests := []struct {
+		name string
+		q, r int
+		want int
+	}{
+		{"center", 0, 0, 6},
+		{"interior", 1, -2, 6},
+		{"interior", -2, 1, 6},
+		{"corner", 3, 0, 3},
+		{"corner", 0, -3, 3},
+		{"corner", -3, 3, 3},
+		{"edge", 2, 1, 4},
+		{"edge", -1, -2, 4},
+	}
+	for _, tt := range tests {
+		if got := len(h.Neighbors(tt.q, tt.r)); got != tt.want {
+			t.Errorf("%s cell (%d, %d) has %d neighbors, want %d", tt.name, tt.q, tt.r, got, tt.want)
+		}
+	}
+
+	// Every cell away from the rim has all 6
+	for p := range h.Cells {
+		if max(abs(p[0]), abs(p[1]), abs(p[0]+p[1])) < h.Radius && len(h.Neighbors(p[0], p[1])) != 6 {
+			t.Errorf("interior cell %v has %d neighbors, want 6", p, len(h.Neighbors(p[0], p[1])))
+		}
+	}
+}
+
+func TestRevealHexCell(t *testing.T) {
+	tests := []struct {
+		name     string
+		q, r     int
+		hit      bool
+		revealed int
+	}{
+		{"mine", 2, 0, true, 1},
+		{"number", 1, 0, false, 1},
+		{"flood fill", -2, 0, false, 18},
+		{"off the board", 3, 0, false, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := hexBoardWithMines(2, [2]int{2, 0})
+			if got := h.RevealHexCell(tt.q, tt.r); got != tt.hit {
+				t.Errorf("RevealHexCell(%d, %d) = %v, want %v", tt.q, tt.r, got, tt.hit)
+			}
+			revealed := 0
+			for _, cell := range h.Cells {
+				if cell.Revealed {
+					revealed++
+				}
+			}
+			if revealed != tt.revealed {
+				t.Errorf("%d cells revealed, want %d", revealed, tt.revealed)
+			}
+		})
+	}
+}
+
+func TestPrintHexBoard(t *testing.T) {
+	h := NewHexBoard(1, 0)
+	h.RevealHexCell(0, 0)
+	var out bytes.Buffer
+	h.PrintHexBoard(&out, false)
+	if want := " 0 0 \n0 0 0 \n 0 0 \n"; out.String() != want {
+		t.Errorf("PrintHexBoard() =\n%q\nwant\n%q", out.String(), want)
+	}
+}
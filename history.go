@@ -0,0 +1,43 @@
+package main
+
+import (
+	"slices"
+	"time"
+)
+
+// Cell event types recorded in a cell's history
+const (
+	CellRevealed     = "revealed"
+	CellFlagged      = "flagged"
+	CellUnflagged    = "unflagged"
+	CellQuestioned   = "questioned"
+	CellUnquestioned = "unquestioned"
+)
+
+// CellEvent struct is one change to a cell, at Time into the game
+type CellEvent struct {
+	Time      time.Duration
+	EventType string
+}
+
+// EnableCellHistory makes the board log every change to every cell, for CellHistory.
+// Cells revealed by a flood fill get their own "revealed" event.
+func EnableCellHistory() BoardOption {
+	return func(b *Board) {
+		b.history = make(map[[2]int][]CellEvent)
+	}
+}
+
+// CellHistory returns the changes made to (x, y) in the order they happened, or nil if history isn't enabled or nothing happened to the cell yet.
+// The slice is a copy.
+func (b *Board) CellHistory(x, y int) []CellEvent {
+	return slices.Clone(b.history[[2]int{x, y}])
+}
+
+// recordCellEvent adds an event to the history of (x, y), if history is enabled.
+func (b *Board) recordCellEvent(x, y int, eventType string) {
+	if b.history == nil {
+		return
+	}
+	b.history[[2]int{x, y}] = append(b.history[[2]int{x, y}], CellEvent{Time: b.Elapsed(), EventType: eventType})
+}
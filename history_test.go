@@ -0,0 +1,95 @@
+package main
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestCellHistory(t *testing.T) {
+	const template = `
+.M..
+....
+....
+`
+	type step struct {
+		at   time.Duration
+		play func(b *Board)
+	}
+	tests := []struct {
+		name  string
+		cell  [2]int
+		steps []step
+		want  []CellEvent
+	}{
+		{"flagged, unflagged, then revealed", [2]int{3, 2}, []step{
+			{time.Second, func(b *Board) { b.FlagCell(3, 2) }},
+			{3 * time.Second, func(b *Board) { b.FlagCell(3, 2) }},
+			{7 * time.Second, func(b *Board) { b.RevealCell(3, 2) }},
+		}, []CellEvent{
+			{time.Second, CellFlagged},
+			{3 * time.Second, CellUnflagged},
+			{7 * time.Second, CellRevealed},
+		}},
+		{"question mark replaces a flag", [2]int{1, 0}, []step{
+			{time.Second, func(b *Board) { b.FlagCell(1, 0) }},
+			{2 * time.Second, func(b *Board) { b.QuestionCell(1, 0) }},
+			{4 * time.Second, func(b *Board) { b.QuestionCell(1, 0) }},
+		}, []CellEvent{
+			{time.Second, CellFlagged},
+			{2 * time.Second, CellUnflagged},
+			{2 * time.Second, CellQuestioned},
+			{4 * time.Second, CellUnquestioned},
+		}},
+		{"revealed by a flood fill", [2]int{3, 1}, []step{
+			{5 * time.Second, func(b *Board) { b.RevealCell(3, 2) }},
+		}, []CellEvent{
+			{5 * time.Second, CellRevealed},
+		}},
+		{"no-ops aren't recorded", [2]int{0, 2}, []step{
+			{time.Second, func(b *Board) { b.RevealCell(0, 2) }},
+			{2 * time.Second, func(b *Board) { b.RevealCell(0, 2) }},
+			{3 * time.Second, func(b *Board) { b.FlagCell(0, 2) }},
+			{4 * time.Second, func(b *Board) { b.PlaceFlag(3, 0); b.PlaceFlag(3, 0) }},
+		}, []CellEvent{
+			{time.Second, CellRevealed},
+		}},
+		{"untouched cell", [2]int{1, 0}, []step{
+			{time.Second, func(b *Board) { b.RevealCell(0, 0) }},
+		}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := boardFromTemplate(t, template)
+			EnableCellHistory()(b)
+			var at time.Duration
+			b.now = func() time.Time { return b.StartTime.Add(at) }
+			for _, s := range tt.steps {
+				at = s.at
+				s.play(b)
+			}
+			if got := b.CellHistory(tt.cell[0], tt.cell[1]); !slices.Equal(got, tt.want) {
+				t.Errorf("CellHistory(%d, %d) = %v, want %v", tt.cell[0], tt.cell[1], got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCellHistoryDisabled(t *testing.T) {
+	b := boardFromTemplate(t, ".M\n..")
+	b.FlagCell(0, 0)
+	b.RevealCell(0, 1)
+	if got := b.CellHistory(0, 0); got != nil {
+		t.Errorf("CellHistory(0, 0) = %v without EnableCellHistory, want nil", got)
+	}
+}
+
+func TestCellHistoryIsACopy(t *testing.T) {
+	b := boardFromTemplate(t, ".M\n..")
+	EnableCellHistory()(b)
+	b.FlagCell(0, 0)
+	b.CellHistory(0, 0)[0].EventType = CellRevealed
+	if got := b.CellHistory(0, 0); got[0].EventType != CellFlagged {
+		t.Errorf("changing the returned slice changed the history to %v", got)
+	}
+}
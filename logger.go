@@ -0,0 +1,67 @@
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"time"
+)
+
+// GameLogger struct writes every move of a game to w as one JSON object per line, for post-hoc analysis
+// A start line is written when the logger is created, and an end line with the full metrics when the game finishes.
+type GameLogger struct {
+	Game *Game
+
+	enc *json.Encoder
+	id  int
+	err error
+}
+
+// NewGameLogger starts logging the game to w.
+func NewGameLogger(g *Game, w io.Writer) *GameLogger {
+	l := &GameLogger{Game: g, enc: json.NewEncoder(w)}
+	l.write(map[string]interface{}{
+		"event":  "start",
+		"width":  g.Board.Width,
+		"height": g.Board.Height,
+		"mines":  g.Board.TotalMines,
+	})
+	l.id = g.Subscribe(l.handle)
+	return l
+}
+
+// Close stops logging. It returns the first write error, if any.
+func (l *GameLogger) Close() error {
+	l.Game.Unsubscribe(l.id)
+	return l.err
+}
+
+// handle turns the events we care about into log lines, the per-cell events are covered by the move lines.
+func (l *GameLogger) handle(e Event) {
+	switch e := e.(type) {
+	case MoveEvent:
+		l.write(map[string]interface{}{
+			"cmd": e.Move.Cmd,
+			"x":   e.Move.X,
+			"y":   e.Move.Y,
+			"result": map[string]interface{}{
+				"hitMine":      e.HitMine,
+				"newlyCovered": e.NewlyRevealed,
+			},
+		})
+	case GameEndedEvent:
+		l.write(map[string]interface{}{
+			"event":   "end",
+			"state":   e.State.String(),
+			"metrics": e.Metrics,
+		})
+	}
+}
+
+// write adds the timestamp and encodes the line. After the first error nothing else is written.
+func (l *GameLogger) write(line map[string]interface{}) {
+	if l.err != nil {
+		return
+	}
+	line["ts"] = time.Now().UTC().Format(time.RFC3339Nano)
+	l.err = l.enc.Encode(line)
+}
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestGameLogger(t *testing.T) {
+	g := NewGame(boardFromTemplate(t, "M..\n..."))
+	var out bytes.Buffer
+	logger := NewGameLogger(g, &out)
+	g.Play(Move{Cmd: CmdFlag, X: 0, Y: 0})
+	g.Play(Move{Cmd: CmdReveal, X: 2, Y: 1})
+	g.Play(Move{Cmd: CmdReveal, X: 0, Y: 1})
+	g.Finish()
+	if err := logger.Close(); err != nil {
+		t.Fatal(err)
+	}
+	// Nothing is written once the logger is closed
+	g.Play(Move{Cmd: CmdFlag, X: 0, Y: 0})
+
+	type result struct {
+		HitMine      bool `json:"hitMine"`
+		NewlyCovered int  `json:"newlyCovered"`
+	}
+	type line struct {
+		TS      string   `json:"ts"`
+		Event   string   `json:"event"`
+		Width   int      `json:"width"`
+		Mines   int      `json:"mines"`
+		Cmd     string   `json:"cmd"`
+		X       int      `json:"x"`
+		Y       int      `json:"y"`
+		Result  *result  `json:"result"`
+		State   string   `json:"state"`
+		Metrics *Metrics `json:"metrics"`
+	}
+	var lines []line
+	scanner := bufio.NewScanner(&out)
+	for scanner.Scan() {
+		var l line
+		if err := json.Unmarshal(scanner.Bytes(), &l); err != nil {
+			t.Fatalf("invalid log line %q: %v", scanner.Text(), err)
+		}
+		if _, err := time.Parse(time.RFC3339Nano, l.TS); err != nil {
+			t.Errorf("line %q has a bad timestamp: %v", scanner.Text(), err)
+		}
+		lines = append(lines, l)
+	}
+
+	if len(lines) != 5 {
+		t.Fatalf("got %d log lines, want 5", len(lines))
+	}
+	if l := lines[0]; l.Event != "start" || l.Width != 3 || l.Mines != 1 {
+		t.Errorf("first line = %+v, want the start of a 3 wide game with 1 mine", l)
+	}
+	moves := []struct {
+		cmd      string
+		x, y     int
+		newlyOut int
+	}{{CmdFlag, 0, 0, 0}, {CmdReveal, 2, 1, 4}, {CmdReveal, 0, 1, 1}}
+	for i, want := range moves {
+		l := lines[i+1]
+		if l.Cmd != want.cmd || l.X != want.x || l.Y != want.y || l.Result == nil || l.Result.HitMine || l.Result.NewlyCovered != want.newlyOut {
+			t.Errorf("line %d = %+v, want %s %d %d opening %d cells", i+1, l, want.cmd, want.x, want.y, want.newlyOut)
+		}
+	}
+	if l := lines[4]; l.Event != "end" || l.State != "won" || l.Metrics == nil || l.Metrics.ThreeBV == 0 {
+		t.Errorf("last line = %+v, want the end of a won game with metrics", l)
+	}
+}
+
+// failingWriter fails every write.
+type failingWriter struct{}
+
+func (failingWriter) Write(p []byte) (int, error) { return 0, errors.New("disk full") }
+
+func TestGameLoggerWriteError(t *testing.T) {
+	g := NewGame(boardFromTemplate(t, "M.."))
+	logger := NewGameLogger(g, failingWriter{})
+	g.Play(Move{Cmd: CmdFlag, X: 0, Y: 0})
+	if err := logger.Close(); err == nil {
+		t.Error("Close() returned no error after failed writes")
+	}
+}
@@ -1,57 +1,149 @@
 package main
 
 import (
-	"bufio"
+	"flag"
 	"fmt"
+	"io"
+	"maps"
 	"math/rand"
 	"os"
-	"strconv"
-	"strings"
+	"slices"
 	"time"
 )
 
 // Command constants for user input
 const (
-	CmdReveal = "reveal"
-	CmdFlag   = "flag"
-	CmdQuit   = "quit"
+	CmdReveal   = "reveal"
+	CmdFlag     = "flag"
+	CmdQuestion = "question"
+	CmdQuit     = "quit"
+	CmdSave     = "save"
+	CmdStep     = "step"
+	CmdSweep    = "sweep"
+	CmdCross    = "cross"
 )
 
 // Board struct represents the game board
 type Board struct {
 	Width, Height int
 	Cells         [][]Cell
+	TotalMines    int
+	State         GameState
+	// StartTime is set when the board is created, EndTime once the game is over
+	StartTime, EndTime time.Time
+	// TimeLimit ends the game as lost once Elapsed passes it, zero means no limit
+	TimeLimit time.Duration
+	// MineRevealed is set by RevealCell when a mine is revealed
+	MineRevealed bool
+	// Notes holds the player's notes on cells, set with AnnotateCell
+	Notes map[[2]int]string
+
+	watchdog *Watchdog
+	now      func() time.Time
+	// history is the per-cell event log kept when EnableCellHistory is set, nil otherwise
+	history map[[2]int][]CellEvent
+	// moveCount counts the reveals that opened something, flagMoveCount the flags placed
+	moveCount, flagMoveCount int
+	// mineCandidates restricts where placeMines may put mines, nil means anywhere
+	mineCandidates [][2]int
+	// excluded holds the cells SetMineExclusion keeps free of mines
+	excluded map[[2]int]bool
+	// rng places the mines if set, instead of the global source
+	rng *rand.Rand
+	// progress receives status lines from long-running operations like GenerateNoGuess
+	progress io.Writer
 }
 
 // Cell struct represents a single cell on the game board
 type Cell struct {
-	IsMine   bool
-	AdjMines int
-	Revealed bool
-	Flagged  bool
+	IsMine   bool `json:"isMine"`
+	AdjMines int  `json:"adjMines"`
+	Revealed bool `json:"revealed"`
+	Flagged  bool `json:"flagged"`
+	// Questioned marks a cell the player is unsure about, it doesn't stop the cell from being revealed
+	Questioned bool `json:"questioned"`
+}
+
+// Move struct represents a single command on a cell, with 0-based coordinates
+type Move struct {
+	Cmd  string
+	X, Y int
+}
+
+// GameState represents whether the game on a board is still going
+type GameState int
+
+const (
+	StatePlaying GameState = iota
+	StateWon
+	StateLost
+)
+
+// String returns the lowercase name of the state.
+func (s GameState) String() string {
+	switch s {
+	case StateWon:
+		return "won"
+	case StateLost:
+		return "lost"
+	default:
+		return "playing"
+	}
+}
+
+// Point struct represents a 0-based cell coordinate on the game board
+type Point struct {
+	X, Y int
 }
 
 // Time complexity considerations:
 // The majority of our methods are either O(1) or O(n), where n is the number of cells (width * height)
 // I think complexity is mostly optimized given the constraints of the problem, excessive nested loops are avoided to prevent quadratic time complexity.
-// RevealCell is O(1) if revealing a single cell, but can also be O(n) as in the worst case it can recursively reveal all adjacent cells with no adjacent mines.
-// RevealCell could be further optimized to avoid deep recursion on larger boards by using a stack or queue to store cells to be revealed.
+// RevealCell is O(1) if revealing a single cell, but can also be O(n) as in the worst case it can reveal all adjacent cells with no adjacent mines.
+// RevealCell uses a queue to store cells to be revealed rather than recursing, to avoid deep recursion on larger boards.
 
 // This method creates a new board with the given width, height, and number of mines.
-func NewBoard(width, height, mines int) *Board {
-	board := &Board{Width: width, Height: height}
+func NewBoard(width, height, mines int, opts ...BoardOption) *Board {
+	board := newEmptyBoard(width, height)
+	for _, opt := range opts {
+		opt(board)
+	}
+	// Place mines on the board and calculate the number of adjacent mines for each cell
+	board.placeMines(mines)
+	board.calculateAdjMines()
+	return board
+}
+
+// newEmptyBoard creates a board of the given size with no mines.
+func newEmptyBoard(width, height int) *Board {
+	board := &Board{Width: width, Height: height, StartTime: time.Now()}
 	// Create a 2D slice of cells
 	board.Cells = make([][]Cell, height)
 	for i := range board.Cells {
 		// Initialize each cell in the board
 		board.Cells[i] = make([]Cell, width)
 	}
-	// Place mines on the board and calculate the number of adjacent mines for each cell
-	board.placeMines(mines)
-	board.calculateAdjMines()
 	return board
 }
 
+// Clone returns a deep copy of the board, so the copy can be played without affecting the original.
+func (b *Board) Clone() *Board {
+	clone := *b
+	clone.watchdog = nil
+	clone.Cells = make([][]Cell, len(b.Cells))
+	for i, row := range b.Cells {
+		clone.Cells[i] = append([]Cell(nil), row...)
+	}
+	clone.Notes = maps.Clone(b.Notes)
+	if b.history != nil {
+		clone.history = make(map[[2]int][]CellEvent, len(b.history))
+		for c, events := range b.history {
+			clone.history[c] = slices.Clone(events)
+		}
+	}
+	return &clone
+}
+
 // placeMines places the specified number of mines randomly on the board.
 func (b *Board) placeMines(mines int) {
 	availableCells := b.Width * b.Height
@@ -63,16 +155,23 @@ func (b *Board) placeMines(mines int) {
 		mines = maxMines
 	}
 
-	// Create a slice of all possible positions
+	// Create a slice of all possible positions, or of the candidates a placement strategy picked
 	positions := make([][2]int, 0, availableCells)
-	for y := 0; y < b.Height; y++ {
-		for x := 0; x < b.Width; x++ {
-			positions = append(positions, [2]int{x, y})
+	if b.mineCandidates != nil {
+		positions = append(positions, b.mineCandidates...)
+	} else {
+		for y := 0; y < b.Height; y++ {
+			for x := 0; x < b.Width; x++ {
+				positions = append(positions, [2]int{x, y})
+			}
 		}
 	}
+	// Cells reserved with SetMineExclusion never get a mine
+	positions = slices.DeleteFunc(positions, func(c [2]int) bool { return b.excluded[c] })
+	mines = min(mines, len(positions))
 
 	// The first version of this code during the interview attempted to place mines by randomly selecting positions on the board and checking if a mine was already placed at that position. If it didn't, it would place a mine. This approach was inefficient and could result in an infinite loop if the number of mines was close to the total number of cells on the board. I refactored the code to shuffle the positions slice and place mines in the first N positions, where N is the number of mines. This approach guarantees that the number of mines placed is equal to the number requested and avoids the inefficiency of the original approach.
-	rand.Shuffle(len(positions), func(i, j int) {
+	b.shuffle(len(positions), func(i, j int) {
 		positions[i], positions[j] = positions[j], positions[i]
 	})
 
@@ -81,19 +180,27 @@ func (b *Board) placeMines(mines int) {
 		b.Cells[y][x].IsMine = true
 		//fmt.Printf("Mine placed at (%d, %d)\n", x, y)
 	}
+	b.TotalMines = mines
 	//fmt.Println("Finished placing mines.")
 }
 
+// shuffle shuffles n elements with the board's rng, or with the global source if the board has none.
+func (b *Board) shuffle(n int, swap func(i, j int)) {
+	if b.rng != nil {
+		b.rng.Shuffle(n, swap)
+		return
+	}
+	rand.Shuffle(n, swap)
+}
+
 // This method iterates over each cell in the board and calculates the number of adjacent mines for each cell.
 // If the cell is a mine, the adjacent mines count is not calculated. The countAdjMines method is used to calculate the number of adjacent mines for each cell.
 func (b *Board) calculateAdjMines() {
-	for y := range b.Cells {
-		for x := range b.Cells[y] {
-			if !b.Cells[y][x].IsMine {
-				b.Cells[y][x].AdjMines = b.countAdjMines(x, y)
-			}
+	b.ForEachCellPtr(func(x, y int, cell *Cell) {
+		if !cell.IsMine {
+			cell.AdjMines = b.countAdjMines(x, y)
 		}
-	}
+	})
 }
 
 // countAdjMines counts the number of mines adjacent to the given cell.
@@ -125,47 +232,241 @@ func (b *Board) isValidCell(x, y int) bool {
 	return x >= 0 && x < b.Width && y >= 0 && y < b.Height
 }
 
+// ForEachCell calls fn for every cell on the board in row-major order, with a copy of the cell.
+func (b *Board) ForEachCell(fn func(x, y int, cell Cell)) {
+	for y := range b.Cells {
+		for x := range b.Cells[y] {
+			fn(x, y, b.Cells[y][x])
+		}
+	}
+}
+
+// ForEachCellPtr is like ForEachCell, but passes a pointer so fn can modify the cell in place.
+func (b *Board) ForEachCellPtr(fn func(x, y int, cell *Cell)) {
+	for y := range b.Cells {
+		for x := range b.Cells[y] {
+			fn(x, y, &b.Cells[y][x])
+		}
+	}
+}
+
+// FilterCells returns the coordinates of every cell matching pred, in row-major order.
+func (b *Board) FilterCells(pred func(x, y int, cell Cell) bool) [][2]int {
+	var result [][2]int
+	b.ForEachCell(func(x, y int, cell Cell) {
+		if pred(x, y, cell) {
+			result = append(result, [2]int{x, y})
+		}
+	})
+	return result
+}
+
+// CountCellsWhere returns the number of cells matching pred, without collecting their coordinates like FilterCells does.
+func (b *Board) CountCellsWhere(pred func(x, y int, cell Cell) bool) int {
+	count := 0
+	b.ForEachCell(func(x, y int, cell Cell) {
+		if pred(x, y, cell) {
+			count++
+		}
+	})
+	return count
+}
+
+// CountMines returns the number of mines on the board.
+func (b *Board) CountMines() int {
+	return b.CountCellsWhere(func(x, y int, cell Cell) bool { return cell.IsMine })
+}
+
+// CountFlags returns the number of flagged cells.
+func (b *Board) CountFlags() int {
+	return b.CountCellsWhere(func(x, y int, cell Cell) bool { return cell.Flagged })
+}
+
+// CountRevealed returns the number of revealed cells.
+func (b *Board) CountRevealed() int {
+	return b.CountCellsWhere(func(x, y int, cell Cell) bool { return cell.Revealed })
+}
+
 // This method reveals a cell on the board. If the cell is a mine, the method returns true, indicating that the game is over.
-// If the cell is not a mine and has no adjacent mines, the method recursively reveals the adjacent cells.
+// If the cell is not a mine and has no adjacent mines, the method reveals the adjacent cells, and so on for every revealed cell with no adjacent mines.
 func (b *Board) RevealCell(x, y int) bool {
 	if !b.isValidCell(x, y) || b.Cells[y][x].Revealed {
 		return false
 	}
+	if b.watchdog != nil {
+		b.watchdog.start(x, y)
+	}
+	b.moveCount++
 	b.Cells[y][x].Revealed = true
+	b.recordCellEvent(x, y, CellRevealed)
 	if b.Cells[y][x].IsMine {
+		b.MineRevealed = true
+		b.endGame(StateLost)
 		return true
 	}
-	if b.Cells[y][x].AdjMines == 0 {
-		// Reveal adjacent cells if the current cell has no adjacent mines
+	// Reveal adjacent cells if the current cell has no adjacent mines
+	// Cells are revealed as they are queued, so each cell is only ever visited once
+	queue := [][2]int{{x, y}}
+	for len(queue) > 0 {
+		cx, cy := queue[0][0], queue[0][1]
+		queue = queue[1:]
+		if b.watchdog != nil {
+			b.watchdog.visit()
+		}
+		if b.Cells[cy][cx].AdjMines != 0 {
+			continue
+		}
 		for i := -1; i <= 1; i++ {
 			for j := -1; j <= 1; j++ {
-				b.RevealCell(x+i, y+j)
+				nx, ny := cx+i, cy+j
+				if b.isValidCell(nx, ny) && !b.Cells[ny][nx].Revealed {
+					b.Cells[ny][nx].Revealed = true
+					b.recordCellEvent(nx, ny, CellRevealed)
+					queue = append(queue, [2]int{nx, ny})
+				}
 			}
 		}
 	}
+	if b.CheckWin() {
+		b.endGame(StateWon)
+	}
 	return false
 }
 
+// endGame records the outcome of the game and stops the timer.
+func (b *Board) endGame(state GameState) {
+	if b.State != StatePlaying {
+		return
+	}
+	b.State = state
+	if b.EndTime.IsZero() {
+		b.EndTime = b.clock()
+	}
+}
+
 // This method toggles the flag on a cell. If the cell is already revealed, the flag is not toggled.
 func (b *Board) FlagCell(x, y int) {
+	if !b.isValidCell(x, y) {
+		return
+	}
+	if b.Cells[y][x].Flagged {
+		b.RemoveFlag(x, y)
+	} else {
+		b.PlaceFlag(x, y)
+	}
+}
+
+// PlaceFlag flags a cell, replacing any question mark. It's a no-op if the cell is already flagged.
+// Unlike FlagCell it never removes a flag, and it returns a MinesweeperError wrapping ErrOutOfBounds or ErrAlreadyRevealed if the cell can't be flagged.
+func (b *Board) PlaceFlag(x, y int) error {
+	if !b.isValidCell(x, y) {
+		return cellError("PlaceFlag", x, y, ErrOutOfBounds)
+	}
+	if b.Cells[y][x].Revealed {
+		return cellError("PlaceFlag", x, y, ErrAlreadyRevealed)
+	}
+	if !b.Cells[y][x].Flagged {
+		b.flagMoveCount++
+		b.recordCellEvent(x, y, CellFlagged)
+	}
+	b.Cells[y][x].Flagged = true
+	b.Cells[y][x].Questioned = false
+	return nil
+}
+
+// RemoveFlag removes the flag from a cell. It's a no-op if the cell isn't flagged.
+// Unlike FlagCell it never places a flag, and it returns a MinesweeperError wrapping ErrOutOfBounds or ErrAlreadyRevealed if the cell can't hold a flag.
+func (b *Board) RemoveFlag(x, y int) error {
+	if !b.isValidCell(x, y) {
+		return cellError("RemoveFlag", x, y, ErrOutOfBounds)
+	}
+	if b.Cells[y][x].Revealed {
+		return cellError("RemoveFlag", x, y, ErrAlreadyRevealed)
+	}
+	if b.Cells[y][x].Flagged {
+		b.recordCellEvent(x, y, CellUnflagged)
+	}
+	b.Cells[y][x].Flagged = false
+	return nil
+}
+
+// This method toggles the question mark on a cell. Like flags, question marks can't be placed on revealed cells, and a question mark replaces a flag.
+func (b *Board) QuestionCell(x, y int) {
 	if !b.isValidCell(x, y) || b.Cells[y][x].Revealed {
 		return
 	}
-	b.Cells[y][x].Flagged = !b.Cells[y][x].Flagged
+	if b.Cells[y][x].Flagged {
+		b.recordCellEvent(x, y, CellUnflagged)
+	}
+	b.Cells[y][x].Questioned = !b.Cells[y][x].Questioned
+	b.Cells[y][x].Flagged = false
+	if b.Cells[y][x].Questioned {
+		b.recordCellEvent(x, y, CellQuestioned)
+	} else {
+		b.recordCellEvent(x, y, CellUnquestioned)
+	}
+}
+
+// MoveCount returns the number of reveals made on the board. A reveal that cascades counts once, and so does a DiagonalReveal or CrossReveal.
+// Reveals of already revealed cells don't count at all.
+func (b *Board) MoveCount() int {
+	return b.moveCount
+}
+
+// FlagMoveCount returns the number of flags placed on the board. Removing a flag doesn't count.
+func (b *Board) FlagMoveCount() int {
+	return b.flagMoveCount
+}
+
+// CanReveal checks if revealing (x, y) is a legal move: the game is still on and the cell is on the board, hidden and not flagged.
+// It has no side effects, so UIs can use it to grey out cells.
+func (b *Board) CanReveal(x, y int) bool {
+	return b.State == StatePlaying && b.isValidCell(x, y) && !b.Cells[y][x].Revealed && !b.Cells[y][x].Flagged
+}
+
+// CanFlag checks if FlagCell would toggle the flag on (x, y): the game is still on and the cell is on the board and hidden.
+func (b *Board) CanFlag(x, y int) bool {
+	return b.State == StatePlaying && b.isValidCell(x, y) && !b.Cells[y][x].Revealed
+}
+
+// Elapsed returns how long the game has been running, or how long it lasted if it is over.
+func (b *Board) Elapsed() time.Duration {
+	if b.EndTime.IsZero() {
+		return b.clock().Sub(b.StartTime)
+	}
+	return b.EndTime.Sub(b.StartTime)
+}
+
+// clock returns the current time, read from the board's fake clock if one has been set.
+func (b *Board) clock() time.Time {
+	if b.now == nil {
+		return time.Now()
+	}
+	return b.now()
+}
+
+// TimeExpired checks if the board has a time limit and the game has run past it.
+func (b *Board) TimeExpired() bool {
+	return b.TimeLimit > 0 && b.Elapsed() > b.TimeLimit
+}
+
+// IsTerminalState checks if the game is over, either because a mine has been revealed or because every safe cell has.
+// Unlike State it looks at the cells, so it also holds for boards edited directly.
+func (b *Board) IsTerminalState() bool {
+	return b.MineRevealed || b.CheckWin()
 }
 
 // This method checks if the player has won the game. If all safe cells are revealed, the player wins.
 func (b *Board) CheckWin() bool {
-	for _, row := range b.Cells {
-		for _, cell := range row {
-			// If a safe cell is not revealed, the game continues
-			if !cell.IsMine && !cell.Revealed {
-				return false
-			}
+	won := true
+	b.ForEachCell(func(x, y int, cell Cell) {
+		// If a safe cell is not revealed, the game continues
+		if !cell.IsMine && !cell.Revealed {
+			won = false
 		}
-	}
+	})
 	// All safe cells are revealed, victory!
-	return true
+	return won
 }
 
 // This method is called when the game is over. It prints the final state of the board, revealing all mines.
@@ -178,28 +479,22 @@ func (b *Board) GameOver(showMines bool) {
 // The board is printed with the following symbols:
 // * for mines
 // F for flagged cells
+// ? for cells marked with a question mark
 // . for unrevealed safe cells
 // An int representing adjacent mine count for revealed safe cells
 // The board is printed row by row, with each cell separated by a space.
 func (b *Board) PrintBoard(showMines bool) {
-	for _, row := range b.Cells {
-		for _, cell := range row {
-			if cell.Revealed {
-				if cell.IsMine {
-					fmt.Print("* ")
-				} else {
-					fmt.Printf("%d ", cell.AdjMines)
-				}
-			} else if cell.Flagged {
-				fmt.Print("F ")
-			} else if showMines && cell.IsMine {
-				fmt.Print("M ")
-			} else {
-				fmt.Print(". ")
-			}
-		}
-		fmt.Println()
-	}
+	b.PrintBoardToWriter(os.Stdout, showMines)
+}
+
+// PrintBoardToWriter prints the board like PrintBoard, but to the given writer.
+func (b *Board) PrintBoardToWriter(w io.Writer, showMines bool) {
+	b.PrintWith(w, defaultRenderer, showMines)
+}
+
+// symbol returns the character PrintBoard uses for the cell.
+func (c Cell) symbol(showMines bool) string {
+	return defaultRenderer.Symbol(c, showMines)
 }
 
 // Debug method to print the board with mines and adjacent mine counts
@@ -221,99 +516,66 @@ func (b *Board) PrintBoardDebug() {
 }
 
 func main() {
+	// --debug guards RevealCell with a watchdog that panics instead of looping forever
+	debug := flag.Bool("debug", false, "panic if a reveal visits more cells than the board has")
+	tutorial := flag.Bool("tutorial", false, "walk through a scripted beginner game")
+	logPath := flag.String("log", "", "write every move to this file as JSON lines")
+	challenge := flag.Bool("challenge", false, "timed challenge mode, a random cell is revealed if a move takes too long")
+	benchmark := flag.Int("benchmark", 0, "let the solver play this many games and report how it did")
+	difficulty := flag.String("difficulty", "beginner", "board preset for --benchmark: beginner, intermediate or expert")
+	asJSON := flag.Bool("json", false, "print --benchmark results as JSON")
+	timeLimit := flag.Duration("time-limit", 0, "lose the game if it takes longer than this, e.g. 90s")
+	watch := flag.String("watch", "", "re-render this save file every time it changes")
+	flag.Parse()
+
+	if *watch != "" {
+		if err := Watch(*watch, os.Stdout, watchInterval, nil); err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	if *benchmark > 0 {
+		d, err := ParseDifficulty(*difficulty)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		RunBenchmark(*benchmark, d).Print(os.Stdout, *asJSON)
+		return
+	}
+
+	if *tutorial {
+		NewTutorial().Run(os.Stdin, os.Stdout)
+		return
+	}
+
 	// Given a board of size 3x3 with 5 mines
 	width, height, mines := 3, 3, 5
-	board := NewBoard(width, height, mines)
+	board := NewBoard(width, height, mines, WithTimeLimit(*timeLimit))
 
 	// Goals of printing: count the mines & ensure 'mines' amount, check adj. counts
 	// DEBUG FUNCTION
 	//board.PrintBoardDebug()
 
-	// Game loop
-	// Read user input via the console and execute commands
-	// Initially, I used fmt.Scan to read user input, but this method was blocking and doesn't allow for easy exit. It also was less robust for handling inputs. I switched to bufio.Scanner to allow for non-blocking input and added a quit command to exit the game.
-	scanner := bufio.NewScanner(os.Stdin)
-
-	// Start the timer
-	startTime := time.Now()
-
-	for {
-		board.PrintBoard(false)
-		fmt.Println("Coordinates are a 1-based index. (1, 1) is the top-left corner.")
-		fmt.Println("Enter your move in the format 'cmd x y' (cmd: reveal, flag), or type 'quit' to exit:")
-
-		scanner.Scan()
-		input := scanner.Text()
-		parts := strings.Fields(input) // Split input based on whitespace
-
-		// Ensure we have some input
-		if len(parts) == 0 {
-			continue
-		}
-
-		// Extract the command and coordinates
-		cmd := parts[0]
-
-		if cmd == CmdQuit {
-			board.PrintBoard(true)
-			fmt.Println("Quit game.")
-			goto End
-		}
-
-		// Ensure we have the correct number of arguments
-		if len(parts) != 3 {
-			fmt.Println("Invalid input. Please enter a command followed by two integers.")
-			continue
-		}
+	if *debug {
+		NewWatchdog(board)
+	}
 
-		// Check errX and errY separately immediately after conversion
-		// Atoi is equivalent to ParseInt(s, 10, 0), converted to type int.
-		// The bitSize argument specifies the integer type that the result must fit into.
-		// In Go, it is idiomatic to check the error as soon as possible, and in this case we should check each conversion separately as strconv.Atoi() is a function call that can return an error.
-		x, err := strconv.Atoi(parts[1])
-		if err != nil {
-			fmt.Println("Invalid x coordinate. Please enter an integer.")
-			continue
-		}
-		y, err := strconv.Atoi(parts[2])
+	game := NewGame(board)
+	if *challenge {
+		game.Challenge = NewChallengeTimer()
+	}
+	if *logPath != "" {
+		f, err := os.Create(*logPath)
 		if err != nil {
-			fmt.Println("Invalid y coordinate. Please enter an integer.")
-			continue
-		}
-
-		// Assuming user input is a 1-based index as this is a bit more intuitive for the user
-		// We convert the input to a 0-based index to match the array
-		x -= 1
-		y -= 1
-
-		if !board.isValidCell(x, y) {
-			fmt.Println("Invalid coordinates. Please try again.")
-			continue
-		}
-
-		// Switch case for our commands
-		switch cmd {
-		case CmdReveal:
-			if board.RevealCell(x, y) {
-				board.PrintBoard(true)
-				fmt.Println("You hit a mine! Game over!")
-				goto End
-			}
-			if board.CheckWin() {
-				board.PrintBoard(true)
-				fmt.Println("Congratulations, you won!")
-				goto End
-			}
-		case CmdFlag:
-			board.FlagCell(x, y)
-		default:
-			fmt.Println("Invalid command. Please use 'reveal' or 'flag'.")
+			fmt.Println("Could not create log file:", err)
+			os.Exit(1)
 		}
+		defer f.Close()
+		logger := NewGameLogger(game, f)
+		defer logger.Close()
 	}
-
-	// End the timer
-End:
-	endTime := time.Now()
-	duration := endTime.Sub(startTime)
-	fmt.Printf("Game duration: %.2f seconds\n", duration.Seconds())
+	game.Run(os.Stdin)
 }
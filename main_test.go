@@ -0,0 +1,320 @@
+package main
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+// boardFromTemplate builds a board with GenerateFromTemplate and fails the test if the template is invalid.
+func boardFromTemplate(t *testing.T, template string) *Board {
+	t.Helper()
+	b, err := GenerateFromTemplate(template)
+	if err != nil {
+		t.Fatalf("GenerateFromTemplate(%q): %v", template, err)
+	}
+	return b
+}
+
+func TestBoardMoveCount(t *testing.T) {
+	const template = `
+.....
+.....
+....M
+`
+	tests := []struct {
+		name      string
+		play      func(b *Board)
+		wantMoves int
+		wantFlags int
+	}{
+		{"cascading reveal counts once", func(b *Board) { b.RevealCell(0, 0) }, 1, 0},
+		{"individual reveals", func(b *Board) { b.RevealCell(3, 2); b.RevealCell(4, 1) }, 2, 0},
+		{"revealed cell doesn't count", func(b *Board) { b.RevealCell(3, 2); b.RevealCell(3, 2) }, 1, 0},
+		{"off the board doesn't count", func(b *Board) { b.RevealCell(-1, 0) }, 0, 0},
+		{"flag placed", func(b *Board) { b.FlagCell(4, 2) }, 0, 1},
+		{"flag removed doesn't count", func(b *Board) { b.FlagCell(4, 2); b.FlagCell(4, 2) }, 0, 1},
+		{"flag placed again counts again", func(b *Board) { b.FlagCell(4, 2); b.FlagCell(4, 2); b.FlagCell(4, 2) }, 0, 2},
+		{"flagging a revealed cell doesn't count", func(b *Board) { b.RevealCell(3, 2); b.FlagCell(3, 2) }, 1, 0},
+		{"question mark doesn't count", func(b *Board) { b.QuestionCell(4, 2) }, 0, 0},
+		{"revealing a mine counts", func(b *Board) { b.RevealCell(4, 2) }, 1, 0},
+		{"cells the cascade revealed don't count again", func(b *Board) { b.RevealCell(0, 0); b.RevealCell(4, 1); b.RevealCell(4, 0) }, 1, 0},
+		{"sweep counts once", func(b *Board) { b.DiagonalReveal(2, 1) }, 1, 0},
+		{"cross counts once", func(b *Board) { b.CrossReveal(3, 1) }, 1, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := boardFromTemplate(t, template)
+			tt.play(b)
+			if got := b.MoveCount(); got != tt.wantMoves {
+				t.Errorf("MoveCount() = %d, want %d", got, tt.wantMoves)
+			}
+			if got := b.FlagMoveCount(); got != tt.wantFlags {
+				t.Errorf("FlagMoveCount() = %d, want %d", got, tt.wantFlags)
+			}
+		})
+	}
+}
+
+func TestPlaceFlagRemoveFlag(t *testing.T) {
+	tests := []struct {
+		name          string
+		ops           []string
+		wantFlagged   bool
+		wantQuestion  bool
+		wantFlagMoves int
+	}{
+		{"place", []string{"place"}, true, false, 1},
+		{"place on a flagged cell is a no-op", []string{"place", "place"}, true, false, 1},
+		{"place replaces a question mark", []string{"question", "place"}, true, false, 1},
+		{"remove", []string{"place", "remove"}, false, false, 1},
+		{"remove from an unflagged cell is a no-op", []string{"remove"}, false, false, 0},
+		{"remove keeps a question mark", []string{"question", "remove"}, false, true, 0},
+		{"toggle places a flag", []string{"toggle"}, true, false, 1},
+		{"toggle twice removes it", []string{"toggle", "toggle"}, false, false, 1},
+		{"toggle three times", []string{"toggle", "toggle", "toggle"}, true, false, 2},
+		{"toggle after place", []string{"place", "toggle"}, false, false, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := boardFromTemplate(t, "M..\n...")
+			for _, op := range tt.ops {
+				var err error
+				switch op {
+				case "place":
+					err = b.PlaceFlag(1, 0)
+				case "remove":
+					err = b.RemoveFlag(1, 0)
+				case "toggle":
+					b.FlagCell(1, 0)
+				case "question":
+					b.QuestionCell(1, 0)
+				}
+				if err != nil {
+					t.Fatalf("%s: %v", op, err)
+				}
+			}
+			cell := b.Cells[0][1]
+			if cell.Flagged != tt.wantFlagged || cell.Questioned != tt.wantQuestion {
+				t.Errorf("Flagged, Questioned = %v, %v, want %v, %v", cell.Flagged, cell.Questioned, tt.wantFlagged, tt.wantQuestion)
+			}
+			wantFlags := 0
+			if tt.wantFlagged {
+				wantFlags = 1
+			}
+			if got := b.CountFlags(); got != wantFlags {
+				t.Errorf("CountFlags() = %d, want %d", got, wantFlags)
+			}
+			if got := b.FlagMoveCount(); got != tt.wantFlagMoves {
+				t.Errorf("FlagMoveCount() = %d, want %d", got, tt.wantFlagMoves)
+			}
+		})
+	}
+}
+
+func TestForEachCell(t *testing.T) {
+	b := newEmptyBoard(4, 3)
+	var visited [][2]int
+	b.ForEachCell(func(x, y int, cell Cell) {
+		visited = append(visited, [2]int{x, y})
+	})
+	var want [][2]int
+	for y := 0; y < 3; y++ {
+		for x := 0; x < 4; x++ {
+			want = append(want, [2]int{x, y})
+		}
+	}
+	if !slices.Equal(visited, want) {
+		t.Errorf("ForEachCell visited %v, want %v", visited, want)
+	}
+}
+
+func TestForEachCellPtr(t *testing.T) {
+	b := newEmptyBoard(3, 2)
+	b.ForEachCellPtr(func(x, y int, cell *Cell) {
+		cell.AdjMines = y*b.Width + x
+	})
+	b.ForEachCell(func(x, y int, cell Cell) {
+		if cell.AdjMines != y*b.Width+x {
+			t.Errorf("cell (%d,%d) AdjMines = %d, want %d", x, y, cell.AdjMines, y*b.Width+x)
+		}
+	})
+}
+
+func TestFilterCells(t *testing.T) {
+	b := boardFromTemplate(t, `
+M..M
+....
+.M..
+`)
+	b.FlagCell(2, 2)
+	tests := []struct {
+		name string
+		pred func(x, y int, c Cell) bool
+		want [][2]int
+	}{
+		{"mines", func(x, y int, c Cell) bool { return c.IsMine }, [][2]int{{0, 0}, {3, 0}, {1, 2}}},
+		{"flagged", func(x, y int, c Cell) bool { return c.Flagged }, [][2]int{{2, 2}}},
+		{"by coordinate", func(x, y int, c Cell) bool { return x == 1 && y == 1 }, [][2]int{{1, 1}}},
+		{"none", func(x, y int, c Cell) bool { return false }, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := b.FilterCells(tt.pred); !slices.Equal(got, tt.want) {
+				t.Errorf("FilterCells() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCountCellsWhere(t *testing.T) {
+	b := boardFromTemplate(t, `
+M..M
+....
+.M..
+`)
+	b.RevealCell(2, 1)
+	b.FlagCell(0, 0)
+	b.FlagCell(1, 1)
+	tests := []struct {
+		name string
+		got  int
+		want int
+	}{
+		{"CountMines", b.CountMines(), 3},
+		{"CountFlags", b.CountFlags(), 2},
+		{"CountRevealed", b.CountRevealed(), 1},
+		{"hidden safe cells", b.CountCellsWhere(func(x, y int, c Cell) bool { return !c.IsMine && !c.Revealed }), 8},
+	}
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("%s = %d, want %d", tt.name, tt.got, tt.want)
+		}
+	}
+}
+
+// benchmarkBoard is a 100x100 board for comparing CountCellsWhere with a hand-written loop.
+func benchmarkBoard() *Board {
+	return NewBoard(100, 100, 2000, WithSeed(1))
+}
+
+func BenchmarkCountCellsWhere(b *testing.B) {
+	board := benchmarkBoard()
+	for i := 0; i < b.N; i++ {
+		board.CountCellsWhere(func(x, y int, c Cell) bool { return c.IsMine })
+	}
+}
+
+func BenchmarkCountMinesLoop(b *testing.B) {
+	board := benchmarkBoard()
+	for i := 0; i < b.N; i++ {
+		count := 0
+		for y := range board.Cells {
+			for x := range board.Cells[y] {
+				if board.Cells[y][x].IsMine {
+					count++
+				}
+			}
+		}
+		_ = count
+	}
+}
+
+func TestTimeExpired(t *testing.T) {
+	tests := []struct {
+		name    string
+		limit   time.Duration
+		elapsed time.Duration
+		want    bool
+	}{
+		{"no limit", 0, time.Hour, false},
+		{"negative limit means none", -time.Second, time.Hour, false},
+		{"before the limit", 30 * time.Second, 29 * time.Second, false},
+		{"at the limit", 30 * time.Second, 30 * time.Second, false},
+		{"past the limit", 30 * time.Second, 30*time.Second + time.Millisecond, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := NewBoard(3, 3, 1, WithTimeLimit(tt.limit))
+			b.now = func() time.Time { return b.StartTime.Add(tt.elapsed) }
+			if got := b.TimeExpired(); got != tt.want {
+				t.Errorf("TimeExpired() after %v with a limit of %v = %v, want %v", tt.elapsed, tt.limit, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCanRevealCanFlag(t *testing.T) {
+	tests := []struct {
+		name       string
+		state      GameState
+		x, y       int
+		wantReveal bool
+		wantFlag   bool
+	}{
+		{"hidden cell", StatePlaying, 2, 1, true, true},
+		{"hidden mine", StatePlaying, 0, 0, true, true},
+		{"revealed cell", StatePlaying, 1, 0, false, false},
+		{"flagged cell", StatePlaying, 1, 1, false, true},
+		{"left of the board", StatePlaying, -1, 0, false, false},
+		{"below the board", StatePlaying, 0, 2, false, false},
+		{"game lost", StateLost, 2, 1, false, false},
+		{"game won", StateWon, 2, 1, false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := boardFromTemplate(t, "M..\n...")
+			b.RevealCell(1, 0)
+			b.FlagCell(1, 1)
+			b.State = tt.state
+			before := b.Clone()
+			if got := b.CanReveal(tt.x, tt.y); got != tt.wantReveal {
+				t.Errorf("CanReveal(%d, %d) = %v, want %v", tt.x, tt.y, got, tt.wantReveal)
+			}
+			if got := b.CanFlag(tt.x, tt.y); got != tt.wantFlag {
+				t.Errorf("CanFlag(%d, %d) = %v, want %v", tt.x, tt.y, got, tt.wantFlag)
+			}
+			if !slices.EqualFunc(before.Cells, b.Cells, slices.Equal[[]Cell]) || before.State != b.State {
+				t.Error("the predicates changed the board")
+			}
+		})
+	}
+}
+
+func TestIsTerminalState(t *testing.T) {
+	tests := []struct {
+		name   string
+		reveal [][2]int
+		want   bool
+	}{
+		{"fresh board", nil, false},
+		{"mid-game", [][2]int{{1, 0}}, false},
+		{"mine revealed", [][2]int{{0, 0}}, true},
+		{"mine revealed after safe cells", [][2]int{{1, 0}, {0, 0}}, true},
+		{"won", [][2]int{{1, 0}, {2, 0}, {0, 1}, {1, 1}, {2, 1}, {0, 2}, {1, 2}}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := boardFromTemplate(t, "M..\n...\n..M")
+			for _, c := range tt.reveal {
+				b.RevealCell(c[0], c[1])
+			}
+			if got := b.IsTerminalState(); got != tt.want {
+				t.Errorf("IsTerminalState() = %v, want %v", got, tt.want)
+			}
+			if got := b.State != StatePlaying; got != tt.want {
+				t.Errorf("State = %v, IsTerminalState() = %v", b.State, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsTerminalStateEditedBoard(t *testing.T) {
+	b := boardFromTemplate(t, "M.\n..")
+	b.ForEachCellPtr(func(x, y int, cell *Cell) {
+		cell.Revealed = !cell.IsMine
+	})
+	if !b.IsTerminalState() {
+		t.Error("IsTerminalState() = false for a board with every safe cell revealed")
+	}
+}
@@ -0,0 +1,104 @@
+package main
+
+import "fmt"
+
+// MoveResult struct describes what a move did to the board
+type MoveResult struct {
+	Move          Move
+	HitMine       bool
+	WonGame       bool
+	NewlyRevealed int
+}
+
+// PartialReveal reveals the given cells in order and returns the result of each reveal.
+// It stops at the first mine, so the last result is the losing move if one was hit. WonGame is set on the reveal that completes the board.
+func (b *Board) PartialReveal(coords [][2]int) []MoveResult {
+	results := make([]MoveResult, 0, len(coords))
+	for _, c := range coords {
+		before, state := b.CountRevealed(), b.State
+		result := MoveResult{Move: Move{Cmd: CmdReveal, X: c[0], Y: c[1]}}
+		result.HitMine = b.RevealCell(c[0], c[1])
+		result.NewlyRevealed = b.CountRevealed() - before
+		result.WonGame = state == StatePlaying && b.State == StateWon
+		results = append(results, result)
+		if result.HitMine {
+			break
+		}
+	}
+	return results
+}
+
+// ApplyMoves plays the moves on the board in order, as RevealCell, FlagCell and QuestionCell.
+// It stops at the first move that is off the board or has an unknown command and returns an error for it. Moves after the game has ended are still applied.
+func (b *Board) ApplyMoves(moves []Move) error {
+	for _, m := range moves {
+		if !b.isValidCell(m.X, m.Y) {
+			return cellError("ApplyMoves", m.X, m.Y, ErrOutOfBounds)
+		}
+		switch m.Cmd {
+		case CmdReveal:
+			b.RevealCell(m.X, m.Y)
+		case CmdFlag:
+			b.FlagCell(m.X, m.Y)
+		case CmdQuestion:
+			b.QuestionCell(m.X, m.Y)
+		default:
+			return fmt.Errorf("ApplyMoves: unknown command %q", m.Cmd)
+		}
+	}
+	return nil
+}
+
+// RevealBorder reveals every cell on the outer ring of the board with RevealCell, for an assisted start with more to go on.
+// Every border cell is revealed even after a mine, and it returns true if any of them was one.
+func (b *Board) RevealBorder() (hitMine bool) {
+	b.ForEachCell(func(x, y int, cell Cell) {
+		if b.isEdgeCell(x, y) && b.RevealCell(x, y) {
+			hitMine = true
+		}
+	})
+	return hitMine
+}
+
+// DiagonalReveal reveals the cells on the four diagonals through (x, y), stepping out from it until each diagonal leaves the board.
+// (x, y) itself and flagged cells are left alone. Each cell is revealed with RevealCell, so zeros still flood fill, but the whole sweep counts as one move.
+// It returns true if any of the revealed cells was a mine, or false if (x, y) is off the board.
+func (b *Board) DiagonalReveal(x, y int) bool {
+	if !b.isValidCell(x, y) {
+		return false
+	}
+	defer b.countAsOneMove(b.moveCount)
+	return b.revealRays(x, y, [][2]int{{1, 1}, {1, -1}, {-1, 1}, {-1, -1}})
+}
+
+// CrossReveal reveals every cell in row y and column x, (x, y) included, skipping flagged cells and counting as one move like DiagonalReveal.
+// It returns true if any of the revealed cells was a mine, or false if (x, y) is off the board.
+func (b *Board) CrossReveal(x, y int) bool {
+	if !b.isValidCell(x, y) {
+		return false
+	}
+	defer b.countAsOneMove(b.moveCount)
+	hitMine := !b.Cells[y][x].Flagged && b.RevealCell(x, y)
+	return b.revealRays(x, y, [][2]int{{0, -1}, {1, 0}, {0, 1}, {-1, 0}}) || hitMine
+}
+
+// countAsOneMove folds the reveals made since the move count was before into a single move, for reveals that call RevealCell more than once.
+func (b *Board) countAsOneMove(before int) {
+	if b.moveCount > before {
+		b.moveCount = before + 1
+	}
+}
+
+// revealRays reveals the cells along each direction from (x, y) to the edge of the board, skipping flagged cells.
+// It returns true if any of them was a mine.
+func (b *Board) revealRays(x, y int, directions [][2]int) bool {
+	hitMine := false
+	for _, d := range directions {
+		for cx, cy := x+d[0], y+d[1]; b.isValidCell(cx, cy); cx, cy = cx+d[0], cy+d[1] {
+			if !b.Cells[cy][cx].Flagged && b.RevealCell(cx, cy) {
+				hitMine = true
+			}
+		}
+	}
+	return hitMine
+}
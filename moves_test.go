@@ -0,0 +1,194 @@
+package main
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestPartialReveal(t *testing.T) {
+	reveal := func(x, y int, hit, won bool, newly int) MoveResult {
+		return MoveResult{Move: Move{Cmd: CmdReveal, X: x, Y: y}, HitMine: hit, WonGame: won, NewlyRevealed: newly}
+	}
+	tests := []struct {
+		name   string
+		coords [][2]int
+		want   []MoveResult
+		state  GameState
+	}{
+		{"safe cells", [][2]int{{1, 0}, {0, 1}}, []MoveResult{
+			reveal(1, 0, false, false, 1),
+			reveal(0, 1, false, false, 1),
+		}, StatePlaying},
+		{"last reveal wins", [][2]int{{1, 0}, {0, 1}, {2, 1}}, []MoveResult{
+			reveal(1, 0, false, false, 1),
+			reveal(0, 1, false, false, 1),
+			reveal(2, 1, false, true, 1),
+		}, StateWon},
+		{"stops at a mine", [][2]int{{1, 0}, {1, 1}, {0, 1}}, []MoveResult{
+			reveal(1, 0, false, false, 1),
+			reveal(1, 1, true, false, 1),
+		}, StateLost},
+		{"already revealed", [][2]int{{1, 0}, {1, 0}}, []MoveResult{
+			reveal(1, 0, false, false, 1),
+			reveal(1, 0, false, false, 0),
+		}, StatePlaying},
+		{"no cells", nil, []MoveResult{}, StatePlaying},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := boardFromTemplate(t, "M.M\n.M.")
+			got := b.PartialReveal(tt.coords)
+			if len(got) != len(tt.want) {
+				t.Fatalf("PartialReveal() returned %d results, want %d: %+v", len(got), len(tt.want), got)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("result %d = %+v, want %+v", i, got[i], tt.want[i])
+				}
+			}
+			if b.State != tt.state {
+				t.Errorf("State = %v, want %v", b.State, tt.state)
+			}
+		})
+	}
+}
+
+func TestRevealBorder(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		want     int // revealed cells
+		hit      bool
+	}{
+		{"3x3 around a mine", "...\n.M.\n...", 2*3 + 2*1, false},
+		{"4x4 around a block of mines", "....\n.MM.\n.MM.\n....", 2*4 + 2*2, false},
+		{"5x4 around a row of mines", ".....\n.MMM.\n.MMM.\n.....", 2*5 + 2*2, false},
+		{"mine on the border", "M..\n.M.\n...", 2*3 + 2*1, true},
+		{"every border cell a mine", "MMM\nM.M\nMMM", 2*3 + 2*1, true},
+		{"zeros on the border flood the inside", ".....\n.....\n..M..\n.....\n.....", 24, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := boardFromTemplate(t, tt.template)
+			if got := b.RevealBorder(); got != tt.hit {
+				t.Errorf("RevealBorder() = %v, want %v", got, tt.hit)
+			}
+			if got := b.CountRevealed(); got != tt.want {
+				t.Errorf("%d cells revealed, want %d", got, tt.want)
+			}
+			b.ForEachCell(func(x, y int, cell Cell) {
+				if b.isEdgeCell(x, y) && !cell.Revealed {
+					t.Errorf("border cell (%d,%d) wasn't revealed", x, y)
+				}
+			})
+		})
+	}
+}
+
+func TestDiagonalReveal(t *testing.T) {
+	// Every cell on the diagonals through the centre touches a mine, so nothing floods
+	const template = `
+.M.M.
+.....
+..M..
+.....
+.M.M.
+`
+	tests := []struct {
+		name string
+		x, y int
+		flag [][2]int
+		want [][2]int
+		hit  bool
+	}{
+		{"centre", 2, 2, nil, [][2]int{{0, 0}, {4, 0}, {1, 1}, {3, 1}, {1, 3}, {3, 3}, {0, 4}, {4, 4}}, false},
+		{"corner", 0, 0, nil, [][2]int{{1, 1}, {2, 2}, {3, 3}, {4, 4}}, true},
+		{"edge", 0, 2, nil, [][2]int{{2, 0}, {1, 1}, {1, 3}, {2, 4}}, false},
+		{"flagged cells are skipped", 2, 2, [][2]int{{1, 1}, {4, 4}}, [][2]int{{0, 0}, {4, 0}, {3, 1}, {1, 3}, {3, 3}, {0, 4}}, false},
+		{"flagged mine is skipped", 0, 0, [][2]int{{2, 2}}, [][2]int{{1, 1}, {3, 3}, {4, 4}}, false},
+		{"off the board", 5, 5, nil, nil, false},
+		{"off the board on a diagonal", -1, -1, nil, nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := boardFromTemplate(t, template)
+			for _, c := range tt.flag {
+				b.FlagCell(c[0], c[1])
+			}
+			if got := b.DiagonalReveal(tt.x, tt.y); got != tt.hit {
+				t.Errorf("DiagonalReveal(%d, %d) = %v, want %v", tt.x, tt.y, got, tt.hit)
+			}
+			if got := b.FilterCells(func(x, y int, cell Cell) bool { return cell.Revealed }); !slices.Equal(got, tt.want) {
+				t.Errorf("revealed %v, want %v", got, tt.want)
+			}
+
+			// The sweep command plays the same move
+			g := NewGame(boardFromTemplate(t, template))
+			for _, c := range tt.flag {
+				g.Board.FlagCell(c[0], c[1])
+			}
+			g.Play(Move{Cmd: CmdSweep, X: tt.x, Y: tt.y})
+			if got := g.Board.FilterCells(func(x, y int, cell Cell) bool { return cell.Revealed }); !slices.Equal(got, tt.want) {
+				t.Errorf("sweep command revealed %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCrossReveal(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		x, y     int
+		reveal   [][2]int
+		flag     [][2]int
+		want     int // newly revealed cells
+		hit      bool
+	}{
+		{"corner", "...\n.M.\n...", 0, 0, nil, nil, 3 + 3 - 1, false},
+		{"mine in the column", "...\n.M.\n...", 1, 0, nil, nil, 3 + 3 - 1, true},
+		{"mine in the row", "...\n.M.\n...", 0, 1, nil, nil, 3 + 3 - 1, true},
+		{"wide board", "M..M\n....\nM..M", 1, 1, nil, nil, 4 + 3 - 1, false},
+		{"on the mine", "M..M\n....\nM..M", 0, 0, nil, nil, 4 + 3 - 1, true},
+		{"already revealed cells", "M..M\n....\nM..M", 1, 1, [][2]int{{1, 1}, {3, 1}}, nil, 4 + 3 - 1 - 2, false},
+		{"flagged mine is skipped", "...\n.M.\n...", 1, 0, nil, [][2]int{{1, 1}}, 3 + 3 - 2, false},
+		{"off the board", "...\n.M.\n...", 3, 0, nil, nil, 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := boardFromTemplate(t, tt.template)
+			for _, c := range tt.reveal {
+				b.RevealCell(c[0], c[1])
+			}
+			for _, c := range tt.flag {
+				b.FlagCell(c[0], c[1])
+			}
+			before := b.CountRevealed()
+			if got := b.CrossReveal(tt.x, tt.y); got != tt.hit {
+				t.Errorf("CrossReveal(%d, %d) = %v, want %v", tt.x, tt.y, got, tt.hit)
+			}
+			if got := b.CountRevealed() - before; got != tt.want {
+				t.Errorf("%d cells newly revealed, want %d", got, tt.want)
+			}
+			b.ForEachCell(func(x, y int, cell Cell) {
+				inCross := b.isValidCell(tt.x, tt.y) && (x == tt.x || y == tt.y) && !cell.Flagged
+				if cell.Revealed != inCross {
+					t.Errorf("cell (%d,%d) revealed = %v, want %v", x, y, cell.Revealed, inCross)
+				}
+			})
+
+			// The cross command plays the same move
+			g := NewGame(boardFromTemplate(t, tt.template))
+			for _, c := range tt.reveal {
+				g.Board.RevealCell(c[0], c[1])
+			}
+			for _, c := range tt.flag {
+				g.Board.FlagCell(c[0], c[1])
+			}
+			g.Play(Move{Cmd: CmdCross, X: tt.x, Y: tt.y})
+			if got := g.Board.CountRevealed(); got != b.CountRevealed() {
+				t.Errorf("cross command revealed %d cells, want %d", got, b.CountRevealed())
+			}
+		})
+	}
+}
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// AnnotateCell attaches a note to (x, y), replacing any note already there. An empty note removes it.
+// Notes are only for the player, they don't affect the game. Coordinates off the board are ignored.
+func (b *Board) AnnotateCell(x, y int, note string) {
+	if !b.isValidCell(x, y) {
+		return
+	}
+	if note == "" {
+		delete(b.Notes, [2]int{x, y})
+		return
+	}
+	if b.Notes == nil {
+		b.Notes = make(map[[2]int]string)
+	}
+	b.Notes[[2]int{x, y}] = note
+}
+
+// CellNote returns the note on (x, y), or "" if there is none.
+func (b *Board) CellNote(x, y int) string {
+	return b.Notes[[2]int{x, y}]
+}
+
+// PrintBoardAnnotated prints the board like PrintBoardToWriter, with the notes of each row in a column to its right.
+// Notes are listed left to right as "(x,y) note" with 1-based coordinates, as typed at the prompt.
+func (b *Board) PrintBoardAnnotated(w io.Writer, showMines bool) {
+	for y, row := range b.Cells {
+		var notes []string
+		for x, cell := range row {
+			fmt.Fprint(w, cell.symbol(showMines), " ")
+			if note := b.CellNote(x, y); note != "" {
+				notes = append(notes, fmt.Sprintf("(%d,%d) %s", x+1, y+1, note))
+			}
+		}
+		if len(notes) > 0 {
+			fmt.Fprint(w, "| ", strings.Join(notes, "; "))
+		}
+		fmt.Fprintln(w)
+	}
+}
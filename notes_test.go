@@ -0,0 +1,101 @@
+package main
+
+import (
+	"bytes"
+	"maps"
+	"testing"
+)
+
+func TestAnnotateCell(t *testing.T) {
+	type note struct {
+		x, y int
+		text string
+	}
+	tests := []struct {
+		name  string
+		notes []note
+		want  map[[2]int]string
+	}{
+		{"single note", []note{{1, 0, "mine?"}}, map[[2]int]string{{1, 0}: "mine?"}},
+		{"replacing a note", []note{{1, 0, "mine?"}, {1, 0, "safe"}}, map[[2]int]string{{1, 0}: "safe"}},
+		{"removing a note", []note{{1, 0, "mine?"}, {2, 1, "50/50"}, {1, 0, ""}}, map[[2]int]string{{2, 1}: "50/50"}},
+		{"removing a missing note", []note{{0, 0, ""}}, map[[2]int]string{}},
+		{"off the board", []note{{3, 0, "x"}, {-1, 1, "x"}, {0, 2, "x"}}, map[[2]int]string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := boardFromTemplate(t, "M..\n..M")
+			for _, n := range tt.notes {
+				b.AnnotateCell(n.x, n.y, n.text)
+			}
+			if !maps.Equal(b.Notes, tt.want) {
+				t.Errorf("Notes = %v, want %v", b.Notes, tt.want)
+			}
+			b.ForEachCell(func(x, y int, _ Cell) {
+				if got := b.CellNote(x, y); got != tt.want[[2]int{x, y}] {
+					t.Errorf("CellNote(%d, %d) = %q, want %q", x, y, got, tt.want[[2]int{x, y}])
+				}
+			})
+		})
+	}
+}
+
+func TestPrintBoardAnnotated(t *testing.T) {
+	tests := []struct {
+		name  string
+		notes map[[2]int]string
+		want  string
+	}{
+		{"no notes", nil, ". . . \n. . . \n"},
+		{"one note", map[[2]int]string{{1, 0}: "mine?"}, ". . . | (2,1) mine?\n. . . \n"},
+		{"notes listed left to right", map[[2]int]string{{2, 1}: "b", {0, 1}: "a"}, ". . . \n. . . | (1,2) a; (3,2) b\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := boardFromTemplate(t, "M..\n..M")
+			for c, note := range tt.notes {
+				b.AnnotateCell(c[0], c[1], note)
+			}
+			var out bytes.Buffer
+			b.PrintBoardAnnotated(&out, false)
+			if out.String() != tt.want {
+				t.Errorf("PrintBoardAnnotated() =\n%q\nwant\n%q", out.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestNotesSaveRoundTrip(t *testing.T) {
+	tests := []struct {
+		name  string
+		notes map[[2]int]string
+	}{
+		{"no notes", nil},
+		{"one note", map[[2]int]string{{0, 0}: "flag later"}},
+		{"several notes", map[[2]int]string{{0, 0}: "a", {2, 1}: "b; c", {1, 1}: "\"quoted\""}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := boardFromTemplate(t, "M..\n..M")
+			for c, note := range tt.notes {
+				b.AnnotateCell(c[0], c[1], note)
+			}
+			data, err := b.Serialize()
+			if err != nil {
+				t.Fatal(err)
+			}
+			loaded, err := DeserializeBoard(data)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(loaded.Notes) != len(tt.notes) {
+				t.Errorf("loaded %d notes, want %d", len(loaded.Notes), len(tt.notes))
+			}
+			for c, note := range tt.notes {
+				if got := loaded.CellNote(c[0], c[1]); got != note {
+					t.Errorf("CellNote(%d, %d) = %q, want %q", c[0], c[1], got, note)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"io"
+	"math/rand"
+	"time"
+)
+
+// BoardOption configures a board created by NewBoard
+type BoardOption func(*Board)
+
+// WithTimeLimit makes the game a loss once it has run for longer than d. A zero or negative d means no limit.
+func WithTimeLimit(d time.Duration) BoardOption {
+	return func(b *Board) {
+		b.TimeLimit = max(d, 0)
+	}
+}
+
+// WithSeed makes the board draw its random choices, like the mine layout, from a source seeded with seed, so the same seed always gives the same board.
+func WithSeed(seed int64) BoardOption {
+	return func(b *Board) {
+		b.rng = rand.New(rand.NewSource(seed))
+	}
+}
+
+// WithProgressWriter makes long-running operations like GenerateNoGuess write status lines to w, at most one per second.
+func WithProgressWriter(w io.Writer) BoardOption {
+	return func(b *Board) {
+		b.progress = w
+	}
+}
+
+// WithExcludedCells keeps the given cells free of mines, see SetMineExclusion.
+func WithExcludedCells(coords [][2]int) BoardOption {
+	return func(b *Board) {
+		b.SetMineExclusion(coords)
+	}
+}
+
+// SetMineExclusion reserves cells that placeMines must never put a mine on, such as a starting position, replacing any earlier exclusion.
+// It only affects mines placed afterwards, so set it up before the board is generated, e.g. through WithExcludedCells.
+// If too few cells are left for the requested mines, the board gets fewer mines.
+func (b *Board) SetMineExclusion(coords [][2]int) {
+	b.excluded = make(map[[2]int]bool, len(coords))
+	for _, c := range coords {
+		b.excluded[c] = true
+	}
+}
+
+// WithMineWaveStrategy places every mine in the ring returned by MineWave, so the mines surround (centerX, centerY) and the center itself stays safe.
+// If the ring has fewer cells than the requested mines, the board gets one mine per ring cell.
+func WithMineWaveStrategy(centerX, centerY, radius int) BoardOption {
+	return func(b *Board) {
+		b.mineCandidates = b.MineWave(centerX, centerY, radius)
+	}
+}
+
+// MineWave returns the cells within Chebyshev distance radius of (centerX, centerY), excluding the center, in row-major order.
+// Cells off the board are left out.
+func (b *Board) MineWave(centerX, centerY, radius int) [][2]int {
+	cells := make([][2]int, 0)
+	for y := max(centerY-radius, 0); y <= min(centerY+radius, b.Height-1); y++ {
+		for x := max(centerX-radius, 0); x <= min(centerX+radius, b.Width-1); x++ {
+			if x != centerX || y != centerY {
+				cells = append(cells, [2]int{x, y})
+			}
+		}
+	}
+	return cells
+}
+
+// PseudoRandomLayout replaces the mines with an independent draw for every cell, each one a mine with probability density.
+// Unlike placeMines the number of mines isn't fixed, TotalMines is set to however many were placed and the 5/9 cap doesn't apply.
+// It returns ErrInvalidDensity if density is outside [0, 1] or NaN, leaving the board untouched.
+func (b *Board) PseudoRandomLayout(density float64, r *rand.Rand) error {
+	// Written this way round so NaN, which fails every comparison, is rejected too
+	if !(density >= 0 && density <= 1) {
+		return ErrInvalidDensity
+	}
+	b.TotalMines = 0
+	b.ForEachCellPtr(func(x, y int, cell *Cell) {
+		cell.IsMine = r.Float64() < density
+		cell.AdjMines = 0
+		if cell.IsMine {
+			b.TotalMines++
+		}
+	})
+	b.calculateAdjMines()
+	return nil
+}
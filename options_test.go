@@ -0,0 +1,198 @@
+package main
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func TestMineWave(t *testing.T) {
+	tests := []struct {
+		name             string
+		centerX, centerY int
+		radius           int
+		want             int
+	}{
+		{"middle", 2, 2, 1, 8},
+		{"middle radius 2", 2, 2, 2, 24},
+		{"corner", 0, 0, 1, 3},
+		{"corner radius 2", 0, 0, 2, 8},
+		{"edge", 2, 0, 1, 5},
+		{"larger than the board", 1, 1, 10, 24},
+		{"radius 0", 2, 2, 0, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := newEmptyBoard(5, 5)
+			got := b.MineWave(tt.centerX, tt.centerY, tt.radius)
+			if len(got) != tt.want {
+				t.Errorf("MineWave() returned %d cells, want %d: %v", len(got), tt.want, got)
+			}
+			if slices.Contains(got, [2]int{tt.centerX, tt.centerY}) {
+				t.Error("MineWave() includes the center")
+			}
+			for _, c := range got {
+				if !b.isValidCell(c[0], c[1]) {
+					t.Errorf("cell %v is off the board", c)
+				}
+				if d := max(abs(c[0]-tt.centerX), abs(c[1]-tt.centerY)); d > tt.radius {
+					t.Errorf("cell %v is %d from the center, more than the radius", c, d)
+				}
+			}
+		})
+	}
+}
+
+func TestWithMineWaveStrategy(t *testing.T) {
+	tests := []struct {
+		name             string
+		centerX, centerY int
+		radius, mines    int
+		want             int
+	}{
+		{"fewer mines than ring cells", 4, 4, 1, 5, 5},
+		{"ring filled", 4, 4, 1, 8, 8},
+		{"more mines than ring cells", 0, 0, 1, 20, 3},
+		{"wide ring", 4, 4, 3, 30, 30},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := NewBoard(9, 9, tt.mines, WithMineWaveStrategy(tt.centerX, tt.centerY, tt.radius))
+			ring := b.MineWave(tt.centerX, tt.centerY, tt.radius)
+			inRing := 0
+			b.ForEachCell(func(x, y int, cell Cell) {
+				if !cell.IsMine {
+					return
+				}
+				if !slices.Contains(ring, [2]int{x, y}) {
+					t.Errorf("mine at (%d,%d) is outside the wave", x, y)
+				}
+				inRing++
+			})
+			if inRing != tt.want || b.TotalMines != tt.want {
+				t.Errorf("placed %d mines, TotalMines %d, want %d", inRing, b.TotalMines, tt.want)
+			}
+			if b.Cells[tt.centerY][tt.centerX].IsMine {
+				t.Error("the center has a mine")
+			}
+		})
+	}
+}
+
+func TestPseudoRandomLayout(t *testing.T) {
+	tests := []struct {
+		density float64
+	}{
+		{0}, {0.1}, {0.2}, {0.5}, {0.8}, {1},
+	}
+	for _, tt := range tests {
+		// 10,000 cells keeps the count within a few standard deviations of the mean
+		b := newEmptyBoard(100, 100)
+		if err := b.PseudoRandomLayout(tt.density, rand.New(rand.NewSource(1))); err != nil {
+			t.Fatalf("PseudoRandomLayout(%v): %v", tt.density, err)
+		}
+		cells := float64(b.Width * b.Height)
+		if got, want := float64(b.TotalMines), tt.density*cells; math.Abs(got-want) > 0.02*cells {
+			t.Errorf("density %v placed %v mines, want about %v", tt.density, got, want)
+		}
+		if b.CountMines() != b.TotalMines {
+			t.Errorf("density %v: TotalMines = %d, board has %d mines", tt.density, b.TotalMines, b.CountMines())
+		}
+		b.ForEachCell(func(x, y int, cell Cell) {
+			if !cell.IsMine && cell.AdjMines != b.countAdjMines(x, y) {
+				t.Fatalf("density %v: cell (%d,%d) has AdjMines %d, want %d", tt.density, x, y, cell.AdjMines, b.countAdjMines(x, y))
+			}
+		})
+	}
+}
+
+func TestPseudoRandomLayoutAverage(t *testing.T) {
+	const density, runs = 0.15, 50
+	r := rand.New(rand.NewSource(2))
+	total := 0
+	for i := 0; i < runs; i++ {
+		b := newEmptyBoard(30, 16)
+		if err := b.PseudoRandomLayout(density, r); err != nil {
+			t.Fatal(err)
+		}
+		total += b.TotalMines
+	}
+	if avg, want := float64(total)/runs, density*30*16; math.Abs(avg-want) > 0.05*want {
+		t.Errorf("average mine count = %v, want about %v", avg, want)
+	}
+}
+
+func TestPseudoRandomLayoutInvalidDensity(t *testing.T) {
+	for _, density := range []float64{-0.1, 1.5, math.NaN(), math.Inf(1), math.Inf(-1)} {
+		b := boardFromTemplate(t, "M.\n..")
+		if err := b.PseudoRandomLayout(density, rand.New(rand.NewSource(1))); !errors.Is(err, ErrInvalidDensity) {
+			t.Errorf("PseudoRandomLayout(%v) = %v, want ErrInvalidDensity", density, err)
+		}
+		if !b.Cells[0][0].IsMine || b.TotalMines != 1 {
+			t.Errorf("PseudoRandomLayout(%v) changed the board after an error", density)
+		}
+	}
+}
+
+func TestWithExcludedCells(t *testing.T) {
+	var allCells, firstRows [][2]int
+	for y := 0; y < 4; y++ {
+		for x := 0; x < 4; x++ {
+			allCells = append(allCells, [2]int{x, y})
+			if y < 3 {
+				firstRows = append(firstRows, [2]int{x, y})
+			}
+		}
+	}
+	tests := []struct {
+		name     string
+		excluded [][2]int
+		mines    int
+		want     int
+	}{
+		{"nothing excluded", nil, 5, 5},
+		{"starting corner", [][2]int{{0, 0}, {1, 0}, {0, 1}, {1, 1}}, 5, 5},
+		{"off-board cells are ignored", [][2]int{{-1, 0}, {4, 4}}, 5, 5},
+		{"too few cells left", firstRows, 8, 4},
+		{"everything excluded", allCells, 5, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for seed := int64(1); seed <= 20; seed++ {
+				b := NewBoard(4, 4, tt.mines, WithSeed(seed), WithExcludedCells(tt.excluded))
+				for _, c := range tt.excluded {
+					if b.isValidCell(c[0], c[1]) && b.Cells[c[1]][c[0]].IsMine {
+						t.Errorf("seed %d: excluded cell %v has a mine", seed, c)
+					}
+				}
+				if got := b.CountCellsWhere(func(x, y int, cell Cell) bool { return cell.IsMine }); got != tt.want || b.TotalMines != tt.want {
+					t.Errorf("seed %d: placed %d mines, TotalMines %d, want %d", seed, got, b.TotalMines, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestWithExcludedCellsAndMineWave(t *testing.T) {
+	excluded := [][2]int{{3, 3}, {5, 3}, {4, 5}}
+	b := NewBoard(9, 9, 8, WithExcludedCells(excluded), WithMineWaveStrategy(4, 4, 1))
+	for _, c := range excluded {
+		if b.Cells[c[1]][c[0]].IsMine {
+			t.Errorf("excluded cell %v has a mine", c)
+		}
+	}
+	if b.TotalMines != 5 {
+		t.Errorf("TotalMines = %d, want the 5 ring cells left", b.TotalMines)
+	}
+}
+
+func TestSetMineExclusionReplaces(t *testing.T) {
+	b := newEmptyBoard(3, 3)
+	b.SetMineExclusion([][2]int{{0, 0}, {1, 1}})
+	b.SetMineExclusion([][2]int{{2, 2}})
+	if b.excluded[[2]int{0, 0}] || b.excluded[[2]int{1, 1}] || !b.excluded[[2]int{2, 2}] {
+		t.Errorf("excluded = %v, want only (2,2)", b.excluded)
+	}
+}
@@ -0,0 +1,234 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// BoardRenderer struct holds the symbols used to print a board
+// NumberFormatter formats the adjacent mine count of revealed safe cells, a nil formatter prints the plain number.
+type BoardRenderer struct {
+	MineSymbol       string
+	FlagSymbol       string
+	QuestionSymbol   string
+	UnrevealedSymbol string
+	ShowMineSymbol   string
+	NumberFormatter  func(int) string
+}
+
+// defaultRenderer is the renderer behind PrintBoard.
+var defaultRenderer = NewDefaultRenderer()
+
+// NewDefaultRenderer returns a renderer with the symbols PrintBoard uses.
+func NewDefaultRenderer() *BoardRenderer {
+	return &BoardRenderer{
+		MineSymbol:       "*",
+		FlagSymbol:       "F",
+		QuestionSymbol:   "?",
+		UnrevealedSymbol: ".",
+		ShowMineSymbol:   "M",
+		NumberFormatter:  strconv.Itoa,
+	}
+}
+
+// Symbol returns the symbol the renderer uses for the cell.
+// MineSymbol is for revealed mines, ShowMineSymbol for hidden mines when showMines is true.
+func (r *BoardRenderer) Symbol(c Cell, showMines bool) string {
+	if c.Revealed {
+		if c.IsMine {
+			return r.MineSymbol
+		}
+		if r.NumberFormatter == nil {
+			return strconv.Itoa(c.AdjMines)
+		}
+		return r.NumberFormatter(c.AdjMines)
+	} else if c.Flagged {
+		return r.FlagSymbol
+	} else if c.Questioned {
+		return r.QuestionSymbol
+	} else if showMines && c.IsMine {
+		return r.ShowMineSymbol
+	}
+	return r.UnrevealedSymbol
+}
+
+// PrintWith prints the board like PrintBoardToWriter, using the symbols of the given renderer.
+func (b *Board) PrintWith(w io.Writer, r *BoardRenderer, showMines bool) {
+	for _, row := range b.Cells {
+		for _, cell := range row {
+			fmt.Fprint(w, r.Symbol(cell, showMines), " ")
+		}
+		fmt.Fprintln(w)
+	}
+}
+
+// PrintBoardWithProb prints the board like PrintBoard(false), but shows the estimated mine probability on the frontier.
+// Frontier cells show the probability as a percentage capped at 99, unrevealed cells away from the frontier show ??.
+// Every cell is two characters wide so the columns line up.
+func (b *Board) PrintBoardWithProb(w io.Writer) {
+	probs := b.MineProbability()
+	frontier := make(map[[2]int]bool)
+	for _, c := range b.FrontierCells() {
+		frontier[c] = true
+	}
+
+	for y, row := range b.Cells {
+		for x, cell := range row {
+			switch {
+			case frontier[[2]int{x, y}]:
+				fmt.Fprintf(w, "%2d ", min(int(probs[y][x]*100), 99))
+			case !cell.Revealed && !cell.Flagged:
+				fmt.Fprint(w, "?? ")
+			default:
+				fmt.Fprintf(w, "%2s ", cell.symbol(false))
+			}
+		}
+		fmt.Fprintln(w)
+	}
+}
+
+// PrintBoardWithCursor prints the board like PrintBoardToWriter, but wraps the cell under the cursor in brackets, e.g. [.] or [3].
+// The bracketed cell takes the place of the cell and its trailing space, so the cursor's row is one character wider than the others.
+func (b *Board) PrintBoardWithCursor(w io.Writer, cursorX, cursorY int, showMines bool) {
+	for y, row := range b.Cells {
+		for x, cell := range row {
+			if x == cursorX && y == cursorY {
+				fmt.Fprintf(w, "[%s]", cell.symbol(showMines))
+			} else {
+				fmt.Fprint(w, cell.symbol(showMines), " ")
+			}
+		}
+		fmt.Fprintln(w)
+	}
+}
+
+// ANSI 256-color codes used by ColoredMineProbMap
+const (
+	colorUnknown  = 27  // blue
+	colorRevealed = 255 // white
+)
+
+// probGradient runs from green through yellow to red, for mine probabilities from 0 to 1
+var probGradient = []int{46, 118, 226, 208, 196}
+
+// ColoredMineProbMap prints the board like PrintBoardWithProb, with every cell on an ANSI 256-color background.
+// Frontier cells show their probability on a green to yellow to red gradient, other unrevealed cells are blue and revealed or flagged cells white.
+// The text is black so it reads on every background, and each cell ends with a reset.
+func (b *Board) ColoredMineProbMap(w io.Writer) {
+	probs := b.MineProbability()
+	frontier := make(map[[2]int]bool)
+	for _, c := range b.FrontierCells() {
+		frontier[c] = true
+	}
+
+	for y, row := range b.Cells {
+		for x, cell := range row {
+			var color int
+			var text string
+			switch {
+			case frontier[[2]int{x, y}]:
+				p := probs[y][x]
+				color = probGradient[min(int(p*float64(len(probGradient))), len(probGradient)-1)]
+				text = strconv.Itoa(min(int(p*100), 99))
+			case !cell.Revealed && !cell.Flagged:
+				color, text = colorUnknown, "??"
+			default:
+				color, text = colorRevealed, cell.symbol(false)
+			}
+			fmt.Fprintf(w, "\x1b[30;48;5;%dm%3s \x1b[0m", color, text)
+		}
+		fmt.Fprintln(w)
+	}
+}
+
+// PrintBoardWithHeat prints the board with the HeatMap classification on every unrevealed, unflagged cell:
+// H for hot (> 0.7), W for warm (0.3 to 0.7) and C for cool (< 0.3). Other cells print as in PrintBoard(false).
+func (b *Board) PrintBoardWithHeat(w io.Writer) {
+	heat := b.HeatMap()
+	for y, row := range b.Cells {
+		for x, cell := range row {
+			if cell.Revealed || cell.Flagged {
+				fmt.Fprint(w, cell.symbol(false), " ")
+			} else {
+				fmt.Fprint(w, heatSymbol(heat[y][x]), " ")
+			}
+		}
+		fmt.Fprintln(w)
+	}
+}
+
+// heatSymbol classifies a HeatMap score.
+func heatSymbol(heat float64) string {
+	switch {
+	case heat > 0.7:
+		return "H"
+	case heat >= 0.3:
+		return "W"
+	default:
+		return "C"
+	}
+}
+
+// BorderStyle struct holds the characters PrintWithBorder draws the box with
+type BorderStyle struct {
+	Horizontal, Vertical                       string
+	TopLeft, TopRight, BottomLeft, BottomRight string
+}
+
+// Border styles for PrintWithBorderStyle
+var (
+	ASCIIBorder   = BorderStyle{Horizontal: "-", Vertical: "|", TopLeft: "+", TopRight: "+", BottomLeft: "+", BottomRight: "+"}
+	UnicodeBorder = BorderStyle{Horizontal: "─", Vertical: "│", TopLeft: "┌", TopRight: "┐", BottomLeft: "└", BottomRight: "┘"}
+)
+
+// PrintWithBorder prints the board inside an ASCII box, with a row of column labels at the top of the box.
+func (b *Board) PrintWithBorder(w io.Writer, showMines bool) {
+	b.PrintWithBorderStyle(w, ASCIIBorder, showMines)
+}
+
+// PrintWithBorderStyle prints the board like PrintWithBorder, drawing the box with the given style.
+// Every line is Width*2 + 3 characters wide, so column labels past 9 only show their last digit.
+func (b *Board) PrintWithBorderStyle(w io.Writer, style BorderStyle, showMines bool) {
+	horizontal := strings.Repeat(style.Horizontal, b.Width*2+1)
+	fmt.Fprintln(w, style.TopLeft+horizontal+style.TopRight)
+
+	fmt.Fprint(w, style.Vertical, " ")
+	for x := 0; x < b.Width; x++ {
+		fmt.Fprint(w, (x+1)%10, " ")
+	}
+	fmt.Fprintln(w, style.Vertical)
+
+	for _, row := range b.Cells {
+		fmt.Fprint(w, style.Vertical, " ")
+		for _, cell := range row {
+			fmt.Fprint(w, cell.symbol(showMines), " ")
+		}
+		fmt.Fprintln(w, style.Vertical)
+	}
+	fmt.Fprintln(w, style.BottomLeft+horizontal+style.BottomRight)
+}
+
+// PrintBoardJSON writes the visible board as a JSON object, for tools following a live game:
+// {"width":3,"height":3,"cells":[[{"sym":".","x":0,"y":0},...],...]}, where sym is what PrintBoard shows for the cell.
+func (b *Board) PrintBoardJSON(w io.Writer, showMines bool) error {
+	type jsonCell struct {
+		Sym string `json:"sym"`
+		X   int    `json:"x"`
+		Y   int    `json:"y"`
+	}
+	cells := make([][]jsonCell, b.Height)
+	for y, row := range b.Cells {
+		cells[y] = make([]jsonCell, b.Width)
+		for x, cell := range row {
+			cells[y][x] = jsonCell{Sym: cell.symbol(showMines), X: x, Y: y}
+		}
+	}
+	return json.NewEncoder(w).Encode(struct {
+		Width  int          `json:"width"`
+		Height int          `json:"height"`
+		Cells  [][]jsonCell `json:"cells"`
+	}{b.Width, b.Height, cells})
+}
@@ -0,0 +1,379 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestPrintBoardWithProb(t *testing.T) {
+	b := boardFromTemplate(t, `
+....
+....
+M..M
+....
+`)
+	b.RevealCell(1, 0)
+	b.FlagCell(3, 2)
+
+	// (0,2) and (1,2) sit next to (0,1), which has 1 mine among 2 hidden neighbors.
+	// (2,2) only touches (1,1) with 1 mine among 3, the flag satisfies (2,1) and (3,1). Row 3 is away from the frontier.
+	want := "" +
+		" 0  0  0  0 \n" +
+		" 1  1  1  1 \n" +
+		"50 50 33  F \n" +
+		"?? ?? ?? ?? \n"
+	var out bytes.Buffer
+	b.PrintBoardWithProb(&out)
+	if out.String() != want {
+		t.Errorf("PrintBoardWithProb() =\n%s\nwant\n%s", out.String(), want)
+	}
+}
+
+func TestPrintBoardWithCursor(t *testing.T) {
+	b := boardFromTemplate(t, `
+M..
+...
+`)
+	b.RevealCell(2, 1)
+	tests := []struct {
+		name      string
+		x, y      int
+		showMines bool
+		want      string
+	}{
+		{"hidden cell", 0, 1, false, ". 1 0 \n[.]1 0 \n"},
+		{"number", 1, 0, false, ". [1]0 \n. 1 0 \n"},
+		{"last column", 2, 0, false, ". 1 [0]\n. 1 0 \n"},
+		{"mine shown", 0, 0, true, "[M]1 0 \n. 1 0 \n"},
+		{"off the board", 5, 5, false, ". 1 0 \n. 1 0 \n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			b.PrintBoardWithCursor(&out, tt.x, tt.y, tt.showMines)
+			if out.String() != tt.want {
+				t.Errorf("PrintBoardWithCursor() =\n%q\nwant\n%q", out.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestPrintBoardWithCursorWidth(t *testing.T) {
+	b := boardFromTemplate(t, "M..\n...\n...")
+	var plain, cursor bytes.Buffer
+	b.PrintBoardToWriter(&plain, false)
+	b.PrintBoardWithCursor(&cursor, 1, 1, false)
+	plainRows := strings.Split(plain.String(), "\n")
+	for y, row := range strings.Split(cursor.String(), "\n") {
+		want := len(plainRows[y])
+		if y == 1 {
+			want++
+		}
+		if len(row) != want {
+			t.Errorf("row %d is %d characters, want %d: %q", y, len(row), want, row)
+		}
+	}
+}
+
+func TestHeatSymbol(t *testing.T) {
+	tests := []struct {
+		heat float64
+		want string
+	}{
+		{0, "C"},
+		{0.29, "C"},
+		{0.3, "W"},
+		{0.5, "W"},
+		{0.7, "W"},
+		{0.71, "H"},
+		{2, "H"},
+	}
+	for _, tt := range tests {
+		if got := heatSymbol(tt.heat); got != tt.want {
+			t.Errorf("heatSymbol(%v) = %q, want %q", tt.heat, got, tt.want)
+		}
+	}
+}
+
+func TestPrintBoardWithHeat(t *testing.T) {
+	b := boardFromTemplate(t, `
+....
+....
+M..M
+....
+`)
+	b.RevealCell(1, 0)
+	b.FlagCell(3, 2)
+
+	// Row 2 has probabilities 1/2, 1/2 and 1/3 from 2, 3 and 3 of the 3 most constraints, giving 0.83, 1 and 0.67.
+	// Row 3 is off the frontier, with the density of the one unflagged mine over 7 hidden cells.
+	want := "" +
+		"0 0 0 0 \n" +
+		"1 1 1 1 \n" +
+		"H H W F \n" +
+		"C C C C \n"
+	var out bytes.Buffer
+	b.PrintBoardWithHeat(&out)
+	if out.String() != want {
+		t.Errorf("PrintBoardWithHeat() =\n%s\nwant\n%s", out.String(), want)
+	}
+}
+
+func TestPrintWith(t *testing.T) {
+	keycaps := []string{"0️⃣", "1️⃣", "2️⃣", "3️⃣", "4️⃣", "5️⃣", "6️⃣", "7️⃣", "8️⃣"}
+	emoji := &BoardRenderer{
+		MineSymbol:       "💥",
+		FlagSymbol:       "🚩",
+		QuestionSymbol:   "❓",
+		UnrevealedSymbol: "⬜",
+		ShowMineSymbol:   "💣",
+		NumberFormatter:  func(n int) string { return keycaps[n] },
+	}
+	plain := &BoardRenderer{MineSymbol: "X", FlagSymbol: "!", QuestionSymbol: "?", UnrevealedSymbol: "#", ShowMineSymbol: "m"}
+
+	tests := []struct {
+		name      string
+		renderer  *BoardRenderer
+		showMines bool
+		lost      bool
+		want      string
+	}{
+		{"emoji", emoji, false, false, "⬜ 1️⃣ 0️⃣ \n🚩 2️⃣ 1️⃣ \n❓ ⬜ ⬜ \n"},
+		{"emoji showing mines", emoji, true, false, "💣 1️⃣ 0️⃣ \n🚩 2️⃣ 1️⃣ \n❓ ⬜ 💣 \n"},
+		{"emoji revealed mine", emoji, false, true, "💥 1️⃣ 0️⃣ \n🚩 2️⃣ 1️⃣ \n❓ ⬜ ⬜ \n"},
+		{"nil number formatter", plain, true, false, "m 1 0 \n! 2 1 \n? # m \n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := boardFromTemplate(t, "M..\n...\n..M")
+			b.RevealCell(2, 0)
+			b.FlagCell(0, 1)
+			b.QuestionCell(0, 2)
+			if tt.lost {
+				b.RevealCell(0, 0)
+			}
+			var out bytes.Buffer
+			b.PrintWith(&out, tt.renderer, tt.showMines)
+			if out.String() != tt.want {
+				t.Errorf("PrintWith() =\n%s\nwant\n%s", out.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestPrintWithDefaultRenderer(t *testing.T) {
+	b := boardFromTemplate(t, "M..\n...\n..M")
+	b.RevealCell(2, 0)
+	b.FlagCell(0, 1)
+	var want, got bytes.Buffer
+	b.PrintBoardToWriter(&want, true)
+	b.PrintWith(&got, NewDefaultRenderer(), true)
+	if got.String() != want.String() {
+		t.Errorf("PrintWith(NewDefaultRenderer()) =\n%s\nwant\n%s", got.String(), want.String())
+	}
+}
+
+func TestPrintWithBorder(t *testing.T) {
+	b := boardFromTemplate(t, "M..\n...\n..M")
+	b.RevealCell(2, 0)
+	b.FlagCell(0, 1)
+
+	tests := []struct {
+		name  string
+		print func(w *bytes.Buffer)
+		want  string
+	}{
+		{"ascii", func(w *bytes.Buffer) { b.PrintWithBorder(w, false) }, "" +
+			"+-------+\n" +
+			"| 1 2 3 |\n" +
+			"| . 1 0 |\n" +
+			"| F 2 1 |\n" +
+			"| . . . |\n" +
+			"+-------+\n"},
+		{"ascii showing mines", func(w *bytes.Buffer) { b.PrintWithBorder(w, true) }, "" +
+			"+-------+\n" +
+			"| 1 2 3 |\n" +
+			"| M 1 0 |\n" +
+			"| F 2 1 |\n" +
+			"| . . M |\n" +
+			"+-------+\n"},
+		{"unicode", func(w *bytes.Buffer) { b.PrintWithBorderStyle(w, UnicodeBorder, false) }, "" +
+			"┌───────┐\n" +
+			"│ 1 2 3 │\n" +
+			"│ . 1 0 │\n" +
+			"│ F 2 1 │\n" +
+			"│ . . . │\n" +
+			"└───────┘\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			tt.print(&out)
+			if out.String() != tt.want {
+				t.Errorf("got\n%s\nwant\n%s", out.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestPrintWithBorderLineLength(t *testing.T) {
+	for _, size := range [][2]int{{1, 1}, {3, 3}, {9, 2}, {12, 4}} {
+		b := newEmptyBoard(size[0], size[1])
+		for _, style := range []BorderStyle{ASCIIBorder, UnicodeBorder} {
+			var out bytes.Buffer
+			b.PrintWithBorderStyle(&out, style, false)
+			lines := strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n")
+			if len(lines) != b.Height+3 {
+				t.Errorf("%dx%d board printed %d lines, want %d", b.Width, b.Height, len(lines), b.Height+3)
+			}
+			for _, line := range lines {
+				if n := utf8.RuneCountInString(line); n != b.Width*2+3 {
+					t.Errorf("%dx%d board line %q is %d characters, want %d", b.Width, b.Height, line, n, b.Width*2+3)
+				}
+			}
+		}
+	}
+}
+
+// ansiCell matches one cell printed by ColoredMineProbMap: background color, text, reset.
+var ansiCell = regexp.MustCompile(`\x1b\[30;48;5;(\d+)m(.{3}) \x1b\[0m`)
+
+func TestColoredMineProbMap(t *testing.T) {
+	b := boardFromTemplate(t, `
+....
+....
+M..M
+....
+`)
+	b.RevealCell(1, 0)
+	b.FlagCell(3, 2)
+
+	// Same layout as TestPrintBoardWithProb: white for revealed and flagged, yellow for 50%, light green for 33%, blue off the frontier
+	want := [][]string{
+		{"255:  0", "255:  0", "255:  0", "255:  0"},
+		{"255:  1", "255:  1", "255:  1", "255:  1"},
+		{"226: 50", "226: 50", "118: 33", "255:  F"},
+		{"27: ??", "27: ??", "27: ??", "27: ??"},
+	}
+	var out bytes.Buffer
+	b.ColoredMineProbMap(&out)
+	lines := strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n")
+	if len(lines) != len(want) {
+		t.Fatalf("printed %d lines, want %d:\n%q", len(lines), len(want), out.String())
+	}
+	for y, line := range lines {
+		cells := ansiCell.FindAllStringSubmatch(line, -1)
+		if len(cells) != len(want[y]) || strings.Join(ansiCell.FindAllString(line, -1), "") != line {
+			t.Fatalf("line %d isn't %d well-formed cells: %q", y, len(want[y]), line)
+		}
+		for x, c := range cells {
+			if got := c[1] + ":" + c[2]; got != want[y][x] {
+				t.Errorf("cell (%d,%d) = %q, want %q", x, y, got, want[y][x])
+			}
+		}
+	}
+}
+
+func TestColoredMineProbMapGradient(t *testing.T) {
+	boards := []struct {
+		template string
+		reveal   [][2]int
+	}{
+		{"....\n....\nM..M\n....", [][2]int{{1, 0}}},
+		{"M..\n...\n...", [][2]int{{1, 0}, {0, 1}, {1, 1}}},
+	}
+	colors := make(map[string]bool)
+	for _, tt := range boards {
+		b := boardFromTemplate(t, tt.template)
+		for _, c := range tt.reveal {
+			b.RevealCell(c[0], c[1])
+		}
+		var out bytes.Buffer
+		b.ColoredMineProbMap(&out)
+		for _, c := range ansiCell.FindAllStringSubmatch(out.String(), -1) {
+			if c[1] != "255" && c[1] != "27" {
+				colors[c[1]] = true
+			}
+		}
+	}
+	if len(colors) < 3 {
+		t.Errorf("frontier cells used %d gradient colors, want at least 3: %v", len(colors), colors)
+	}
+
+	distinct := slices.Clone(probGradient)
+	slices.Sort(distinct)
+	if len(probGradient) < 3 || len(slices.Compact(distinct)) != len(probGradient) {
+		t.Errorf("probGradient = %v, want at least 3 distinct colors", probGradient)
+	}
+}
+
+func TestPrintBoardJSON(t *testing.T) {
+	tests := []struct {
+		name      string
+		template  string
+		reveal    [][2]int
+		flag      [][2]int
+		showMines bool
+	}{
+		{"fresh board", "M..\n...\n..M", nil, nil, false},
+		{"fresh board with mines shown", "M..\n...\n..M", nil, nil, true},
+		{"opening and flag", "....\n....\n...M", [][2]int{{0, 0}}, [][2]int{{3, 2}}, false},
+		{"lost game", ".M\n..", [][2]int{{1, 0}}, nil, true},
+		{"single row", "M....", [][2]int{{4, 0}}, nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := boardFromTemplate(t, tt.template)
+			for _, c := range tt.flag {
+				b.FlagCell(c[0], c[1])
+			}
+			for _, c := range tt.reveal {
+				b.RevealCell(c[0], c[1])
+			}
+
+			var out bytes.Buffer
+			if err := b.PrintBoardJSON(&out, tt.showMines); err != nil {
+				t.Fatal(err)
+			}
+			var got struct {
+				Width, Height int
+				Cells         [][]struct {
+					Sym  string
+					X, Y int
+				}
+			}
+			if err := json.Unmarshal(out.Bytes(), &got); err != nil {
+				t.Fatalf("output isn't valid JSON: %v\n%s", err, out.String())
+			}
+			if got.Width != b.Width || got.Height != b.Height {
+				t.Errorf("size = %dx%d, want %dx%d", got.Width, got.Height, b.Width, b.Height)
+			}
+
+			var text bytes.Buffer
+			b.PrintBoardToWriter(&text, tt.showMines)
+			lines := strings.Split(strings.TrimRight(text.String(), "\n"), "\n")
+			if len(got.Cells) != b.Height || len(lines) != b.Height {
+				t.Fatalf("got %d rows of JSON and %d printed rows, want %d", len(got.Cells), len(lines), b.Height)
+			}
+			for y, row := range got.Cells {
+				syms := strings.Fields(lines[y])
+				if len(row) != b.Width || len(syms) != b.Width {
+					t.Fatalf("row %d has %d cells and %d printed symbols, want %d", y, len(row), len(syms), b.Width)
+				}
+				for x, cell := range row {
+					if cell.X != x || cell.Y != y {
+						t.Errorf("cell at (%d,%d) says it is (%d,%d)", x, y, cell.X, cell.Y)
+					}
+					if cell.Sym != syms[x] {
+						t.Errorf("cell (%d,%d) sym = %q, PrintBoard shows %q", x, y, cell.Sym, syms[x])
+					}
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,94 @@
+package main
+
+import "fmt"
+
+// Difficulty represents one of the classic board presets
+type Difficulty int
+
+const (
+	DifficultyBeginner Difficulty = iota
+	DifficultyIntermediate
+	DifficultyExpert
+)
+
+// Params returns the board size and mine count of the preset.
+func (d Difficulty) Params() (width, height, mines int) {
+	switch d {
+	case DifficultyIntermediate:
+		return 16, 16, 40
+	case DifficultyExpert:
+		return 30, 16, 99
+	default:
+		return 9, 9, 10
+	}
+}
+
+// String returns the lowercase name of the difficulty.
+func (d Difficulty) String() string {
+	switch d {
+	case DifficultyIntermediate:
+		return "intermediate"
+	case DifficultyExpert:
+		return "expert"
+	default:
+		return "beginner"
+	}
+}
+
+// ParseDifficulty parses the name of a difficulty, as returned by String.
+func ParseDifficulty(s string) (Difficulty, error) {
+	for _, d := range []Difficulty{DifficultyBeginner, DifficultyIntermediate, DifficultyExpert} {
+		if d.String() == s {
+			return d, nil
+		}
+	}
+	return DifficultyBeginner, fmt.Errorf("unknown difficulty %q, use beginner, intermediate or expert", s)
+}
+
+// Multiplier returns how much the difficulty scales the base score.
+func (d Difficulty) Multiplier() int {
+	switch d {
+	case DifficultyIntermediate:
+		return 2
+	case DifficultyExpert:
+		return 3
+	default:
+		return 1
+	}
+}
+
+// Scoring constants, see CalculateScore
+const (
+	scorePerThreeBV   = 10
+	timeBonusSeconds  = 600
+	hintPenalty       = 50
+	undoPenalty       = 20
+	maxAccuracyBonus  = 100
+	maxEfficiencyBase = 100
+)
+
+// CalculateScore turns the metrics of a finished game into a single score, so players can compare games.
+// The score is the sum of:
+//
+//	base score:          3BV cleared × 10 × difficulty multiplier (1 beginner, 2 intermediate, 3 expert)
+//	time bonus:          600 - seconds taken, or 0 for games longer than 10 minutes
+//	efficiency bonus:    efficiency (3BV cleared / moves) × 100
+//	flag accuracy bonus: correct flags / all flags × 100, or 0 if no flags were placed
+//	hint penalty:        -50 per hint
+//	undo penalty:        -20 per undo
+//
+// The three bonuses only count for a won game, so a lost game scores just what it cleared, minus the penalties,
+// and always less than winning the same board. The score never goes below 0. Identical metrics always give the identical score.
+func CalculateScore(m Metrics, d Difficulty) int {
+	score := m.ThreeBVCleared * scorePerThreeBV * d.Multiplier()
+	if m.Won {
+		score += max(timeBonusSeconds-int(m.Duration.Seconds()), 0)
+		score += int(m.Efficiency * maxEfficiencyBase)
+		if flags := m.FlagsCorrect + m.FlagsIncorrect; flags > 0 {
+			score += m.FlagsCorrect * maxAccuracyBonus / flags
+		}
+	}
+	score -= m.HintsUsed * hintPenalty
+	score -= m.UndoCount * undoPenalty
+	return max(score, 0)
+}
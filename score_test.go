@@ -0,0 +1,92 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCalculateScore(t *testing.T) {
+	tests := []struct {
+		name string
+		m    Metrics
+		d    Difficulty
+		want int
+	}{
+		{"won beginner", Metrics{ThreeBV: 10, ThreeBVCleared: 10, Won: true, Duration: 100 * time.Second, Efficiency: 0.5}, DifficultyBeginner, 100 + 500 + 50},
+		{"won expert", Metrics{ThreeBV: 10, ThreeBVCleared: 10, Won: true, Duration: 100 * time.Second, Efficiency: 0.5}, DifficultyExpert, 300 + 500 + 50},
+		{"slow game has no time bonus", Metrics{ThreeBVCleared: 10, Won: true, Duration: 20 * time.Minute}, DifficultyBeginner, 100},
+		{"flag accuracy", Metrics{Won: true, Duration: 600 * time.Second, FlagsCorrect: 3, FlagsIncorrect: 1}, DifficultyBeginner, 75},
+		{"lost game gets no bonuses", Metrics{ThreeBV: 10, ThreeBVCleared: 4, Duration: time.Second, Efficiency: 1, FlagsCorrect: 1}, DifficultyBeginner, 40},
+		{"penalties", Metrics{ThreeBVCleared: 10, HintsUsed: 1, UndoCount: 2}, DifficultyBeginner, 100 - 50 - 40},
+		{"never below zero", Metrics{HintsUsed: 5}, DifficultyBeginner, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CalculateScore(tt.m, tt.d); got != tt.want {
+				t.Errorf("CalculateScore() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCalculateScoreWinBeatsLoss(t *testing.T) {
+	const template = `
+M....
+.....
+.....
+....M
+`
+	lost := NewGame(boardFromTemplate(t, template))
+	lost.Reveal(0, 0)
+	if lost.Board.State != StateLost {
+		t.Fatalf("state after revealing a mine = %v, want StateLost", lost.Board.State)
+	}
+
+	won := NewGame(boardFromTemplate(t, template))
+	won.Reveal(2, 2)
+	for _, c := range [][2]int{{1, 0}, {0, 1}, {1, 1}, {4, 2}, {3, 2}, {3, 3}} {
+		won.Reveal(c[0], c[1])
+	}
+	if won.Board.State != StateWon {
+		t.Fatalf("state after clearing the board = %v, want StateWon", won.Board.State)
+	}
+
+	lostScore := CalculateScore(lost.EndMetrics(), DifficultyBeginner)
+	wonScore := CalculateScore(won.EndMetrics(), DifficultyBeginner)
+	if wonScore <= lostScore {
+		t.Errorf("won game scored %d, lost game %d, want the win to score higher", wonScore, lostScore)
+	}
+}
+
+func TestParseDifficulty(t *testing.T) {
+	for _, d := range []Difficulty{DifficultyBeginner, DifficultyIntermediate, DifficultyExpert} {
+		got, err := ParseDifficulty(d.String())
+		if err != nil || got != d {
+			t.Errorf("ParseDifficulty(%q) = %v, %v, want %v", d.String(), got, err, d)
+		}
+	}
+	if _, err := ParseDifficulty("nightmare"); err == nil {
+		t.Error("ParseDifficulty(\"nightmare\") returned no error")
+	}
+}
+
+func TestCalculateScoreDeterministic(t *testing.T) {
+	m := Metrics{ThreeBV: 30, ThreeBVCleared: 30, Won: true, Duration: 95 * time.Second, Efficiency: 0.8, FlagsCorrect: 9, FlagsIncorrect: 1}
+	if a, b := CalculateScore(m, DifficultyIntermediate), CalculateScore(m, DifficultyIntermediate); a != b {
+		t.Errorf("identical metrics scored %d and %d", a, b)
+	}
+}
+
+func TestCalculateScoreHintsAndUndos(t *testing.T) {
+	base := Metrics{ThreeBV: 30, ThreeBVCleared: 30, Won: true, Duration: 95 * time.Second, Efficiency: 0.8}
+	withHints, withUndos := base, base
+	withHints.HintsUsed = 2
+	withUndos.UndoCount = 2
+	want := CalculateScore(base, DifficultyBeginner)
+	if got := CalculateScore(withHints, DifficultyBeginner); got != want-2*hintPenalty {
+		t.Errorf("score with 2 hints = %d, want %d", got, want-2*hintPenalty)
+	}
+	if got := CalculateScore(withUndos, DifficultyBeginner); got != want-2*undoPenalty {
+		t.Errorf("score with 2 undos = %d, want %d", got, want-2*undoPenalty)
+	}
+}
@@ -0,0 +1,462 @@
+package main
+
+import (
+	"encoding/binary"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"math"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Export returns the board as a generic map, for consumers that want JSON without depending on a fixed schema.
+// The map has the keys "width", "height", "mines", "cells", "state" and "elapsed" (in seconds).
+// "cells" is a row-major 2D slice of maps with the keys "isMine", "adjMines", "revealed" and "flagged".
+func (b *Board) Export() map[string]interface{} {
+	cells := make([][]map[string]interface{}, b.Height)
+	for y, row := range b.Cells {
+		cells[y] = make([]map[string]interface{}, b.Width)
+		for x, cell := range row {
+			cells[y][x] = map[string]interface{}{
+				"isMine":   cell.IsMine,
+				"adjMines": cell.AdjMines,
+				"revealed": cell.Revealed,
+				"flagged":  cell.Flagged,
+			}
+		}
+	}
+	return map[string]interface{}{
+		"width":   b.Width,
+		"height":  b.Height,
+		"mines":   b.TotalMines,
+		"cells":   cells,
+		"state":   b.State.String(),
+		"elapsed": b.Elapsed().Seconds(),
+	}
+}
+
+// csvHeader is the header row written by ToCSV and expected by FromCSV
+var csvHeader = []string{"x", "y", "isMine", "adjMines", "revealed", "flagged"}
+
+// ToCSV returns the board as CSV for spreadsheets and data frames: a header row, then one row per cell in row-major order.
+func (b *Board) ToCSV() string {
+	var sb strings.Builder
+	w := csv.NewWriter(&sb)
+	w.Write(csvHeader)
+	b.ForEachCell(func(x, y int, cell Cell) {
+		w.Write([]string{
+			strconv.Itoa(x),
+			strconv.Itoa(y),
+			strconv.FormatBool(cell.IsMine),
+			strconv.Itoa(cell.AdjMines),
+			strconv.FormatBool(cell.Revealed),
+			strconv.FormatBool(cell.Flagged),
+		})
+	})
+	w.Flush()
+	return sb.String()
+}
+
+// FromCSV replaces the board with one parsed from the format written by ToCSV.
+// The rows must list every cell exactly once in row-major order, and the adjacency counts must match the mines.
+// It returns an error wrapping ErrInvalidCSV otherwise, in which case the board is left untouched.
+func (b *Board) FromCSV(s string) error {
+	records, err := csv.NewReader(strings.NewReader(s)).ReadAll()
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidCSV, err)
+	}
+	if len(records) < 2 || !slices.Equal(records[0], csvHeader) {
+		return fmt.Errorf("%w: missing header or cells", ErrInvalidCSV)
+	}
+
+	type csvCell struct {
+		x, y int
+		cell Cell
+	}
+	var cells []csvCell
+	width, height := 0, 0
+	for i, record := range records[1:] {
+		var c csvCell
+		var errs [6]error
+		c.x, errs[0] = strconv.Atoi(record[0])
+		c.y, errs[1] = strconv.Atoi(record[1])
+		c.cell.IsMine, errs[2] = strconv.ParseBool(record[2])
+		c.cell.AdjMines, errs[3] = strconv.Atoi(record[3])
+		c.cell.Revealed, errs[4] = strconv.ParseBool(record[4])
+		c.cell.Flagged, errs[5] = strconv.ParseBool(record[5])
+		if err := errors.Join(errs[:]...); err != nil {
+			return fmt.Errorf("%w: line %d: %v", ErrInvalidCSV, i+2, err)
+		}
+		width, height = max(width, c.x+1), max(height, c.y+1)
+		cells = append(cells, c)
+	}
+	if len(cells) != width*height {
+		return fmt.Errorf("%w: %d cells for a %dx%d board", ErrInvalidCSV, len(cells), width, height)
+	}
+
+	parsed := newEmptyBoard(width, height)
+	for i, c := range cells {
+		if c.x != i%width || c.y != i/width {
+			return fmt.Errorf("%w: line %d: cell (%d, %d) out of order", ErrInvalidCSV, i+2, c.x, c.y)
+		}
+		parsed.Cells[c.y][c.x] = c.cell
+		if c.cell.IsMine {
+			parsed.TotalMines++
+		}
+	}
+	for i, c := range cells {
+		if !c.cell.IsMine && c.cell.AdjMines != parsed.countAdjMines(c.x, c.y) {
+			return fmt.Errorf("%w: line %d: cell (%d, %d) has adjMines %d, but %d adjacent mines", ErrInvalidCSV, i+2, c.x, c.y, c.cell.AdjMines, parsed.countAdjMines(c.x, c.y))
+		}
+	}
+	parsed.MineRevealed = parsed.hasRevealedMine()
+	parsed.State = parsed.deriveState()
+	*b = *parsed
+	return nil
+}
+
+// hasRevealedMine checks the cells for a revealed mine, for boards loaded without going through RevealCell.
+func (b *Board) hasRevealedMine() bool {
+	return b.CountCellsWhere(func(x, y int, cell Cell) bool { return cell.IsMine && cell.Revealed }) > 0
+}
+
+// deriveState works out the game state from the cells, for boards loaded from a format that doesn't store it.
+func (b *Board) deriveState() GameState {
+	if b.hasRevealedMine() {
+		return StateLost
+	}
+	if b.CountRevealed() > 0 && b.CheckWin() {
+		return StateWon
+	}
+	return StatePlaying
+}
+
+// saveFile is the JSON layout written by Serialize and read by DeserializeBoard
+type saveFile struct {
+	Version int        `json:"version"`
+	Width   int        `json:"width"`
+	Height  int        `json:"height"`
+	Mines   int        `json:"mines"`
+	State   string     `json:"state"`
+	Elapsed float64    `json:"elapsed"`
+	Cells   [][]Cell   `json:"cells"`
+	Notes   []saveNote `json:"notes,omitempty"`
+}
+
+// saveVersion is the save format Serialize writes. Older saves are brought up to it by migrateSave.
+const saveVersion = 1
+
+// saveNote is one cell note in a save, JSON objects can't have the [2]int keys of Board.Notes
+type saveNote struct {
+	X    int    `json:"x"`
+	Y    int    `json:"y"`
+	Note string `json:"note"`
+}
+
+// Serialize returns the board as a JSON save, which DeserializeBoard turns back into a board.
+// Elapsed is stored in seconds, so a loaded game resumes its timer where it was saved.
+// Notes are stored in row-major order.
+func (b *Board) Serialize() ([]byte, error) {
+	var notes []saveNote
+	b.ForEachCell(func(x, y int, cell Cell) {
+		if note := b.CellNote(x, y); note != "" {
+			notes = append(notes, saveNote{X: x, Y: y, Note: note})
+		}
+	})
+	return json.Marshal(saveFile{
+		Version: saveVersion,
+		Width:   b.Width,
+		Height:  b.Height,
+		Mines:   b.TotalMines,
+		State:   b.State.String(),
+		Elapsed: b.Elapsed().Seconds(),
+		Cells:   b.Cells,
+		Notes:   notes,
+	})
+}
+
+// DeserializeBoard parses a save written by Serialize. Saves from older versions of the format are migrated first, a save without a version is version 0.
+// It returns an error wrapping ErrInvalidSave if the save is malformed, from a newer version, or its cells don't add up.
+func DeserializeBoard(data []byte) (*Board, error) {
+	var header struct {
+		Version int `json:"version"`
+	}
+	if err := json.Unmarshal(data, &header); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidSave, err)
+	}
+	if header.Version > saveVersion {
+		return nil, fmt.Errorf("%w: version %d is newer than %d", ErrInvalidSave, header.Version, saveVersion)
+	}
+	raw := json.RawMessage(data)
+	for v := header.Version; v < saveVersion; v++ {
+		var err error
+		if raw, err = migrateSave(raw, v); err != nil {
+			return nil, fmt.Errorf("%w: migrating from version %d: %v", ErrInvalidSave, v, err)
+		}
+	}
+
+	var save saveFile
+	if err := json.Unmarshal(raw, &save); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidSave, err)
+	}
+	if save.Width <= 0 || save.Height <= 0 || len(save.Cells) != save.Height {
+		return nil, fmt.Errorf("%w: %d rows for a %dx%d board", ErrInvalidSave, len(save.Cells), save.Width, save.Height)
+	}
+
+	b := newEmptyBoard(save.Width, save.Height)
+	for y, row := range save.Cells {
+		if len(row) != save.Width {
+			return nil, fmt.Errorf("%w: row %d has %d cells, want %d", ErrInvalidSave, y, len(row), save.Width)
+		}
+		copy(b.Cells[y], row)
+	}
+	b.TotalMines = b.CountMines()
+	b.MineRevealed = b.hasRevealedMine()
+	if b.TotalMines != save.Mines {
+		return nil, fmt.Errorf("%w: %d mines, but the save says %d", ErrInvalidSave, b.TotalMines, save.Mines)
+	}
+	var err error
+	b.ForEachCell(func(x, y int, cell Cell) {
+		if err == nil && !cell.IsMine && cell.AdjMines != b.countAdjMines(x, y) {
+			err = fmt.Errorf("%w: cell (%d, %d) has adjMines %d, but %d adjacent mines", ErrInvalidSave, x, y, cell.AdjMines, b.countAdjMines(x, y))
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	switch save.State {
+	case StatePlaying.String():
+		b.State = StatePlaying
+	case StateWon.String():
+		b.State = StateWon
+	case StateLost.String():
+		b.State = StateLost
+	default:
+		return nil, fmt.Errorf("%w: unknown state %q", ErrInvalidSave, save.State)
+	}
+	for _, n := range save.Notes {
+		if !b.isValidCell(n.X, n.Y) {
+			return nil, fmt.Errorf("%w: note on (%d, %d) is off the board", ErrInvalidSave, n.X, n.Y)
+		}
+		b.AnnotateCell(n.X, n.Y, n.Note)
+	}
+	elapsed := time.Duration(save.Elapsed * float64(time.Second))
+	b.StartTime = b.clock().Add(-elapsed)
+	if b.State != StatePlaying {
+		b.EndTime = b.StartTime.Add(elapsed)
+	}
+	return b, nil
+}
+
+// migrateSave upgrades a save from the given version to the next one. DeserializeBoard calls it once per version until the save is current.
+func migrateSave(raw json.RawMessage, version int) (json.RawMessage, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &fields); err != nil {
+		return nil, err
+	}
+	switch version {
+	case 0:
+		// Version 0 is the original format, which had no version field and otherwise matches version 1
+	default:
+		return nil, fmt.Errorf("no migration from version %d", version)
+	}
+	fields["version"] = json.RawMessage(strconv.Itoa(version + 1))
+	return json.Marshal(fields)
+}
+
+// SaveFile writes the board to path with Serialize.
+func (b *Board) SaveFile(path string) error {
+	data, err := b.Serialize()
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
+
+// LoadBoardFile reads a board saved with SaveFile.
+func LoadBoardFile(path string) (*Board, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return DeserializeBoard(data)
+}
+
+// binaryMagic starts every board written by ExportMachineReadable
+const binaryMagic = "MNSW"
+
+// Cell bits in the binary format
+const (
+	binaryMine     = 1 << 7
+	binaryAdjShift = 3
+	binaryRevealed = 1 << 1
+	binaryFlagged  = 1 << 0
+)
+
+// ExportMachineReadable writes the board in a compact binary format, 10 + Width*Height bytes long:
+// the magic "MNSW", then the width, height and mine count as big-endian uint16s, then one byte per cell in row-major order
+// with IsMine in bit 7, AdjMines in bits 3-6, Revealed in bit 1 and Flagged in bit 0.
+// It returns an error wrapping ErrInvalidBinary, without writing anything, if the width, height or mine count doesn't fit in a uint16.
+func (b *Board) ExportMachineReadable(w io.Writer) error {
+	if max(b.Width, b.Height, b.TotalMines) > math.MaxUint16 {
+		return fmt.Errorf("%w: %dx%d board with %d mines doesn't fit the header", ErrInvalidBinary, b.Width, b.Height, b.TotalMines)
+	}
+	data := make([]byte, 0, 10+b.Width*b.Height)
+	data = append(data, binaryMagic...)
+	data = binary.BigEndian.AppendUint16(data, uint16(b.Width))
+	data = binary.BigEndian.AppendUint16(data, uint16(b.Height))
+	data = binary.BigEndian.AppendUint16(data, uint16(b.TotalMines))
+	b.ForEachCell(func(x, y int, cell Cell) {
+		c := byte(cell.AdjMines) << binaryAdjShift
+		if cell.IsMine {
+			c |= binaryMine
+		}
+		if cell.Revealed {
+			c |= binaryRevealed
+		}
+		if cell.Flagged {
+			c |= binaryFlagged
+		}
+		data = append(data, c)
+	})
+	_, err := w.Write(data)
+	return err
+}
+
+// ImportMachineReadable parses a board written by ExportMachineReadable.
+// It returns an error wrapping ErrInvalidBinary if the data is truncated, or if the mine count or adjacency counts don't match the cells.
+func ImportMachineReadable(r io.Reader) (*Board, error) {
+	header := make([]byte, 10)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return nil, fmt.Errorf("%w: header: %v", ErrInvalidBinary, err)
+	}
+	if string(header[:4]) != binaryMagic {
+		return nil, fmt.Errorf("%w: bad magic %q", ErrInvalidBinary, header[:4])
+	}
+	width := int(binary.BigEndian.Uint16(header[4:]))
+	height := int(binary.BigEndian.Uint16(header[6:]))
+	mines := int(binary.BigEndian.Uint16(header[8:]))
+
+	cells := make([]byte, width*height)
+	if _, err := io.ReadFull(r, cells); err != nil {
+		return nil, fmt.Errorf("%w: cells: %v", ErrInvalidBinary, err)
+	}
+	b := newEmptyBoard(width, height)
+	b.ForEachCellPtr(func(x, y int, cell *Cell) {
+		c := cells[y*width+x]
+		cell.IsMine = c&binaryMine != 0
+		cell.AdjMines = int(c>>binaryAdjShift) & 0xf
+		cell.Revealed = c&binaryRevealed != 0
+		cell.Flagged = c&binaryFlagged != 0
+	})
+	b.TotalMines = b.CountMines()
+	if b.TotalMines != mines {
+		return nil, fmt.Errorf("%w: %d mines, but the header says %d", ErrInvalidBinary, b.TotalMines, mines)
+	}
+	var err error
+	b.ForEachCell(func(x, y int, cell Cell) {
+		if err == nil && !cell.IsMine && cell.AdjMines != b.countAdjMines(x, y) {
+			err = fmt.Errorf("%w: cell (%d, %d) has adjMines %d, but %d adjacent mines", ErrInvalidBinary, x, y, cell.AdjMines, b.countAdjMines(x, y))
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	b.MineRevealed = b.hasRevealedMine()
+	b.State = b.deriveState()
+	return b, nil
+}
+
+// Cell codes in the compact format, two bits per cell
+const (
+	compactHidden   = 0b00
+	compactRevealed = 0b01
+	compactMine     = 0b10
+	compactFlagged  = 0b11
+)
+
+// compactHeaderSize is the width and height bytes plus the CRC32 of the cells
+const compactHeaderSize = 6
+
+// CompactRepr packs the board into ceil(Width*Height/4) + 6 bytes: a byte each for the width and height, the big-endian CRC32 of the cell bytes,
+// then two bits per cell in row-major order, lowest bits first. A cell is 00 for hidden and safe, 01 for revealed, 10 for a mine and 11 for flagged.
+// A flag is stored as 11 without a separate mine bit, so only flags on mines can be stored, and a mine can't be stored as revealed.
+// The format has no room for the game state, so a game lost by hitting a mine can't be stored at all, and one lost on time comes back as still playing.
+// Use Serialize or ExportMachineReadable for lost games.
+// Question marks are dropped. It returns an error wrapping ErrInvalidCompact for boards wider or taller than 255,
+// or with a flag on a safe cell or a revealed mine, rather than storing a different mine layout or game state.
+func (b *Board) CompactRepr() ([]byte, error) {
+	if b.Width > 255 || b.Height > 255 {
+		return nil, fmt.Errorf("%w: %dx%d board is larger than 255x255", ErrInvalidCompact, b.Width, b.Height)
+	}
+	var err error
+	cells := make([]byte, (b.Width*b.Height+3)/4)
+	b.ForEachCell(func(x, y int, cell Cell) {
+		code := byte(compactHidden)
+		switch {
+		case err != nil:
+			// Only the first cell that can't be stored is reported
+		case cell.Flagged && !cell.IsMine:
+			err = fmt.Errorf("%w: flag on safe cell (%d,%d)", ErrInvalidCompact, x, y)
+		case cell.IsMine && cell.Revealed:
+			err = fmt.Errorf("%w: revealed mine at (%d,%d)", ErrInvalidCompact, x, y)
+		case cell.Flagged:
+			code = compactFlagged
+		case cell.IsMine:
+			code = compactMine
+		case cell.Revealed:
+			code = compactRevealed
+		}
+		i := y*b.Width + x
+		cells[i/4] |= code << (i % 4 * 2)
+	})
+	if err != nil {
+		return nil, err
+	}
+	data := []byte{byte(b.Width), byte(b.Height)}
+	data = binary.BigEndian.AppendUint32(data, crc32.ChecksumIEEE(cells))
+	return append(data, cells...), nil
+}
+
+// FromCompactRepr replaces the board with one unpacked from CompactRepr. Flagged cells come back as flagged mines, as CompactRepr only stores flags on mines,
+// and the adjacency counts, TotalMines and State are worked out from the cells.
+// It returns an error wrapping ErrInvalidCompact if the data has the wrong length or fails the checksum, in which case the board is left untouched.
+func (b *Board) FromCompactRepr(data []byte) error {
+	if len(data) < compactHeaderSize {
+		return fmt.Errorf("%w: %d bytes is too short for the header", ErrInvalidCompact, len(data))
+	}
+	width, height := int(data[0]), int(data[1])
+	cells := data[compactHeaderSize:]
+	if want := (width*height + 3) / 4; len(cells) != want {
+		return fmt.Errorf("%w: %d cell bytes for a %dx%d board, want %d", ErrInvalidCompact, len(cells), width, height, want)
+	}
+	if crc32.ChecksumIEEE(cells) != binary.BigEndian.Uint32(data[2:]) {
+		return fmt.Errorf("%w: checksum mismatch", ErrInvalidCompact)
+	}
+
+	parsed := newEmptyBoard(width, height)
+	parsed.ForEachCellPtr(func(x, y int, cell *Cell) {
+		i := y*width + x
+		switch cells[i/4] >> (i % 4 * 2) & 0b11 {
+		case compactRevealed:
+			cell.Revealed = true
+		case compactMine:
+			cell.IsMine = true
+		case compactFlagged:
+			cell.IsMine, cell.Flagged = true, true
+		}
+	})
+	parsed.calculateAdjMines()
+	parsed.TotalMines = parsed.CountMines()
+	parsed.State = parsed.deriveState()
+	*b = *parsed
+	return nil
+}
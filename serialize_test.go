@@ -0,0 +1,528 @@
+package main
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"hash/crc32"
+	"math"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestExport(t *testing.T) {
+	b := boardFromTemplate(t, "M..\n...")
+	b.RevealCell(2, 1)
+	b.FlagCell(0, 0)
+
+	data, err := json.Marshal(b.Export())
+	if err != nil {
+		t.Fatalf("json.Marshal(Export()): %v", err)
+	}
+	var got struct {
+		Width, Height, Mines int
+		State                string
+		Elapsed              *float64
+		Cells                [][]map[string]json.RawMessage
+	}
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("Export() isn't valid JSON: %v\n%s", err, data)
+	}
+	if got.Width != 3 || got.Height != 2 || got.Mines != 1 || got.State != "playing" || got.Elapsed == nil {
+		t.Errorf("got width %d, height %d, mines %d, state %q, elapsed set %v", got.Width, got.Height, got.Mines, got.State, got.Elapsed != nil)
+	}
+	if len(got.Cells) != 2 || len(got.Cells[0]) != 3 {
+		t.Fatalf("cells are %d rows, want 2x3", len(got.Cells))
+	}
+	for y, row := range got.Cells {
+		for x, cell := range row {
+			for _, key := range []string{"isMine", "adjMines", "revealed", "flagged"} {
+				if _, ok := cell[key]; !ok {
+					t.Errorf("cell (%d,%d) has no %q", x, y, key)
+				}
+			}
+		}
+	}
+	if string(got.Cells[0][0]["isMine"]) != "true" || string(got.Cells[0][0]["flagged"]) != "true" || string(got.Cells[1][1]["adjMines"]) != "1" {
+		t.Errorf("cell values don't match the board: %s", data)
+	}
+}
+
+func TestCompactReprRoundTrip(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		play     func(b *Board)
+	}{
+		{"fresh 1x1", ".", func(b *Board) {}},
+		{"flagged mine", "..\n.M", func(b *Board) { b.FlagCell(1, 1) }},
+		{"revealed cells", "M....\n.....\n....M", func(b *Board) { b.RevealCell(2, 1) }},
+		{"won", "M..\n...", func(b *Board) { b.RevealCell(2, 1); b.RevealCell(1, 0) }},
+		{"odd size", "M.M.M.M\n.......\n.M...M.", func(b *Board) { b.RevealCell(3, 1); b.FlagCell(0, 0) }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := boardFromTemplate(t, tt.template)
+			tt.play(b)
+			data, err := b.CompactRepr()
+			if err != nil {
+				t.Fatalf("CompactRepr: %v", err)
+			}
+			if want := (b.Width*b.Height+3)/4 + 6; len(data) != want {
+				t.Errorf("CompactRepr is %d bytes, want %d", len(data), want)
+			}
+
+			var got Board
+			if err := got.FromCompactRepr(data); err != nil {
+				t.Fatalf("FromCompactRepr: %v", err)
+			}
+			if got.Width != b.Width || got.Height != b.Height || got.TotalMines != b.TotalMines || got.State != b.State {
+				t.Errorf("got %dx%d, %d mines, %v, want %dx%d, %d mines, %v",
+					got.Width, got.Height, got.TotalMines, got.State, b.Width, b.Height, b.TotalMines, b.State)
+			}
+			b.ForEachCell(func(x, y int, cell Cell) {
+				if g := got.Cells[y][x]; g != cell {
+					t.Errorf("cell (%d,%d) = %+v, want %+v", x, y, g, cell)
+				}
+			})
+		})
+	}
+}
+
+func TestCompactReprRejects(t *testing.T) {
+	tests := []struct {
+		name string
+		play func(b *Board)
+	}{
+		{"flag on a safe cell", func(b *Board) { b.FlagCell(0, 0) }},
+		{"game lost on a mine", func(b *Board) { b.RevealCell(1, 1) }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := boardFromTemplate(t, "..\n.M")
+			tt.play(b)
+			if _, err := b.CompactRepr(); !errors.Is(err, ErrInvalidCompact) {
+				t.Errorf("CompactRepr error = %v, want ErrInvalidCompact", err)
+			}
+		})
+	}
+	if _, err := newEmptyBoard(256, 1).CompactRepr(); !errors.Is(err, ErrInvalidCompact) {
+		t.Errorf("CompactRepr of a 256x1 board: error = %v, want ErrInvalidCompact", err)
+	}
+}
+
+func TestFromCompactReprInvalid(t *testing.T) {
+	b := boardFromTemplate(t, "M..\n...")
+	data, err := b.CompactRepr()
+	if err != nil {
+		t.Fatal(err)
+	}
+	corrupt := append([]byte(nil), data...)
+	corrupt[len(corrupt)-1] ^= 0xff
+
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{"empty", nil},
+		{"short header", data[:3]},
+		{"missing cells", data[:len(data)-1]},
+		{"bad checksum", corrupt},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			target := boardFromTemplate(t, ".")
+			if err := target.FromCompactRepr(tt.data); !errors.Is(err, ErrInvalidCompact) {
+				t.Errorf("FromCompactRepr error = %v, want ErrInvalidCompact", err)
+			}
+			if target.Width != 1 || target.Height != 1 {
+				t.Error("board changed after a failed FromCompactRepr")
+			}
+		})
+	}
+}
+
+func TestCSVRoundTrip(t *testing.T) {
+	tests := []struct {
+		name   string
+		reveal [][2]int
+		flag   [][2]int
+		state  GameState
+	}{
+		{"untouched", nil, nil, StatePlaying},
+		{"in progress", [][2]int{{1, 0}}, [][2]int{{3, 2}}, StatePlaying},
+		{"lost", [][2]int{{1, 0}, {0, 0}}, nil, StateLost},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := boardFromTemplate(t, "M...\n....\n...M")
+			for _, c := range tt.reveal {
+				b.RevealCell(c[0], c[1])
+			}
+			for _, c := range tt.flag {
+				b.FlagCell(c[0], c[1])
+			}
+
+			csv := b.ToCSV()
+			if lines := strings.Count(csv, "\n"); lines != b.Width*b.Height+1 {
+				t.Errorf("CSV has %d lines, want %d", lines, b.Width*b.Height+1)
+			}
+			if header, _, _ := strings.Cut(csv, "\n"); header != "x,y,isMine,adjMines,revealed,flagged" {
+				t.Errorf("header = %q", header)
+			}
+
+			var loaded Board
+			if err := loaded.FromCSV(csv); err != nil {
+				t.Fatalf("FromCSV: %v", err)
+			}
+			if loaded.Width != b.Width || loaded.Height != b.Height || loaded.TotalMines != b.TotalMines {
+				t.Fatalf("loaded a %dx%d board with %d mines, want %dx%d with %d", loaded.Width, loaded.Height, loaded.TotalMines, b.Width, b.Height, b.TotalMines)
+			}
+			if loaded.State != tt.state {
+				t.Errorf("State = %v, want %v", loaded.State, tt.state)
+			}
+			b.ForEachCell(func(x, y int, want Cell) {
+				got := loaded.Cells[y][x]
+				if got.IsMine != want.IsMine || got.AdjMines != want.AdjMines || got.Revealed != want.Revealed || got.Flagged != want.Flagged {
+					t.Errorf("cell (%d,%d) = %+v, want %+v", x, y, got, want)
+				}
+			})
+		})
+	}
+}
+
+func TestFromCSVInvalid(t *testing.T) {
+	const header = "x,y,isMine,adjMines,revealed,flagged\n"
+	tests := []struct {
+		name string
+		csv  string
+	}{
+		{"empty", ""},
+		{"header only", header},
+		{"wrong header", "x,y,mine,adj,revealed,flagged\n0,0,true,0,false,false\n"},
+		{"bad bool", header + "0,0,yes,0,false,false\n"},
+		{"missing column", header + "0,0,true,0,false\n"},
+		{"missing cell", header + "0,0,true,0,false,false\n1,0,false,1,false,false\n0,1,false,1,false,false\n"},
+		{"out of order", header + "1,0,false,1,false,false\n0,0,true,0,false,false\n"},
+		{"wrong count", header + "0,0,true,0,false,false\n1,0,false,2,false,false\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := boardFromTemplate(t, "M.")
+			if err := b.FromCSV(tt.csv); !errors.Is(err, ErrInvalidCSV) {
+				t.Errorf("FromCSV() = %v, want ErrInvalidCSV", err)
+			}
+			if b.Width != 2 || b.Height != 1 || !b.Cells[0][0].IsMine {
+				t.Error("FromCSV changed the board after an error")
+			}
+		})
+	}
+}
+
+func TestMachineReadableRoundTrip(t *testing.T) {
+	tests := []struct {
+		name          string
+		width, height int
+		mines         int
+	}{
+		{"16x16", 16, 16, 40},
+		{"30x16", 30, 16, 99},
+		{"1x1", 1, 1, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := NewBoard(tt.width, tt.height, tt.mines, WithSeed(3))
+			if safe := b.SafeOpeningCells(); len(safe) > 0 {
+				b.RevealCell(safe[0][0], safe[0][1])
+			}
+			if hidden := b.FilterCells(func(x, y int, cell Cell) bool { return !cell.Revealed }); len(hidden) > 0 {
+				b.FlagCell(hidden[0][0], hidden[0][1])
+			}
+
+			var buf bytes.Buffer
+			if err := b.ExportMachineReadable(&buf); err != nil {
+				t.Fatal(err)
+			}
+			if want := 10 + tt.width*tt.height; buf.Len() != want {
+				t.Errorf("exported %d bytes, want %d", buf.Len(), want)
+			}
+			if header := buf.Bytes()[:10]; string(header[:4]) != "MNSW" || int(header[5]) != tt.width || int(header[7]) != tt.height || int(header[9]) != b.TotalMines {
+				t.Errorf("header = % x", header)
+			}
+
+			loaded, err := ImportMachineReadable(&buf)
+			if err != nil {
+				t.Fatalf("ImportMachineReadable: %v", err)
+			}
+			if loaded.Width != b.Width || loaded.Height != b.Height || loaded.TotalMines != b.TotalMines || loaded.State != b.State {
+				t.Fatalf("loaded %dx%d, %d mines, %v, want %dx%d, %d mines, %v",
+					loaded.Width, loaded.Height, loaded.TotalMines, loaded.State, b.Width, b.Height, b.TotalMines, b.State)
+			}
+			b.ForEachCell(func(x, y int, want Cell) {
+				if got := loaded.Cells[y][x]; got != want {
+					t.Errorf("cell (%d,%d) = %+v, want %+v", x, y, got, want)
+				}
+			})
+		})
+	}
+}
+
+func TestExportMachineReadableHeaderLimits(t *testing.T) {
+	tests := []struct {
+		name          string
+		width, height int
+		mines         int
+		wantErr       bool
+	}{
+		{"widest", math.MaxUint16, 1, 0, false},
+		{"too wide", math.MaxUint16 + 1, 1, 0, true},
+		{"tallest", 1, math.MaxUint16, 0, false},
+		{"too tall", 1, math.MaxUint16 + 1, 0, true},
+		{"most mines", 300, 300, math.MaxUint16, false},
+		{"too many mines", 300, 300, math.MaxUint16 + 1, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := newEmptyBoard(tt.width, tt.height)
+			for i := 0; i < tt.mines; i++ {
+				b.Cells[i/tt.width][i%tt.width].IsMine = true
+			}
+			b.TotalMines = tt.mines
+			b.calculateAdjMines()
+
+			var buf bytes.Buffer
+			err := b.ExportMachineReadable(&buf)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidBinary) || buf.Len() != 0 {
+					t.Errorf("ExportMachineReadable() = %v after writing %d bytes, want ErrInvalidBinary and nothing written", err, buf.Len())
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			loaded, err := ImportMachineReadable(&buf)
+			if err != nil {
+				t.Fatalf("ImportMachineReadable: %v", err)
+			}
+			if loaded.Width != tt.width || loaded.Height != tt.height || loaded.TotalMines != tt.mines {
+				t.Errorf("loaded %dx%d with %d mines, want %dx%d with %d", loaded.Width, loaded.Height, loaded.TotalMines, tt.width, tt.height, tt.mines)
+			}
+		})
+	}
+}
+
+func TestImportMachineReadableInvalid(t *testing.T) {
+	header := func(w, h, mines byte) string {
+		return "MNSW" + string([]byte{0, w, 0, h, 0, mines})
+	}
+	tests := []struct {
+		name string
+		data string
+	}{
+		{"empty", ""},
+		{"short header", "MNSW\x00\x02"},
+		{"bad magic", "MSWN" + header(1, 1, 0)[4:] + "\x00"},
+		{"missing cells", header(2, 1, 1) + "\x80"},
+		{"wrong mine count", header(2, 1, 2) + "\x80\x08"},
+		{"wrong adjacency", header(2, 1, 1) + "\x80\x10"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ImportMachineReadable(strings.NewReader(tt.data)); !errors.Is(err, ErrInvalidBinary) {
+				t.Errorf("ImportMachineReadable() = %v, want ErrInvalidBinary", err)
+			}
+		})
+	}
+	if _, err := ImportMachineReadable(strings.NewReader(header(2, 1, 1) + "\x80\x08")); err != nil {
+		t.Errorf("a valid 2x1 board failed to load: %v", err)
+	}
+}
+
+// v0Save is a save in the original format, before saves had a version field or notes.
+func v0Save(state string, elapsed string, cells string) []byte {
+	return []byte(`{"width":2,"height":2,"mines":1,"state":"` + state + `","elapsed":` + elapsed + `,"cells":` + cells + `}`)
+}
+
+func TestDeserializeBoardV0(t *testing.T) {
+	const (
+		playingCells = `[[{"isMine":true,"adjMines":0,"revealed":false,"flagged":true},{"isMine":false,"adjMines":1,"revealed":false,"flagged":false}],` +
+			`[{"isMine":false,"adjMines":1,"revealed":false,"flagged":false},{"isMine":false,"adjMines":1,"revealed":true,"flagged":false}]]`
+		wonCells = `[[{"isMine":true,"adjMines":0,"revealed":false,"flagged":false},{"isMine":false,"adjMines":1,"revealed":true,"flagged":false}],` +
+			`[{"isMine":false,"adjMines":1,"revealed":true,"flagged":false},{"isMine":false,"adjMines":1,"revealed":true,"flagged":false}]]`
+		lostCells = `[[{"isMine":true,"adjMines":0,"revealed":true,"flagged":false},{"isMine":false,"adjMines":1,"revealed":false,"flagged":false}],` +
+			`[{"isMine":false,"adjMines":1,"revealed":false,"flagged":false},{"isMine":false,"adjMines":1,"revealed":false,"flagged":false}]]`
+	)
+	tests := []struct {
+		name     string
+		data     []byte
+		state    GameState
+		revealed int
+		flagged  int
+		elapsed  time.Duration
+	}{
+		{"playing", v0Save("playing", "12.5", playingCells), StatePlaying, 1, 1, 12500 * time.Millisecond},
+		{"won", v0Save("won", "30", wonCells), StateWon, 3, 0, 30 * time.Second},
+		{"lost", v0Save("lost", "4.25", lostCells), StateLost, 1, 0, 4250 * time.Millisecond},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := DeserializeBoard(tt.data)
+			if err != nil {
+				t.Fatalf("DeserializeBoard() = %v", err)
+			}
+			if b.Width != 2 || b.Height != 2 || b.TotalMines != 1 || !b.Cells[0][0].IsMine {
+				t.Errorf("loaded a %dx%d board with %d mines, want 2x2 with a mine at (0,0)", b.Width, b.Height, b.TotalMines)
+			}
+			if b.State != tt.state || b.CountRevealed() != tt.revealed || b.CountFlags() != tt.flagged {
+				t.Errorf("state %v with %d revealed and %d flagged, want %v with %d and %d", b.State, b.CountRevealed(), b.CountFlags(), tt.state, tt.revealed, tt.flagged)
+			}
+			if got := b.Elapsed(); got < tt.elapsed || got > tt.elapsed+time.Second {
+				t.Errorf("Elapsed() = %v, want %v", got, tt.elapsed)
+			}
+			if len(b.Notes) != 0 {
+				t.Errorf("Notes = %v, want none", b.Notes)
+			}
+
+			// Saving again writes the current version
+			data, err := b.Serialize()
+			if err != nil {
+				t.Fatal(err)
+			}
+			var header struct{ Version int }
+			if err := json.Unmarshal(data, &header); err != nil || header.Version != saveVersion {
+				t.Errorf("re-saved version = %d, %v, want %d", header.Version, err, saveVersion)
+			}
+		})
+	}
+}
+
+func TestMigrateSave(t *testing.T) {
+	tests := []struct {
+		name    string
+		raw     string
+		version int
+		want    map[string]string // raw JSON of each field
+		wantErr bool
+	}{
+		{"version 0", `{"width":2,"cells":[]}`, 0, map[string]string{"version": "1", "width": "2", "cells": "[]"}, false},
+		{"version 0 with a stray version field", `{"version":0,"width":2}`, 0, map[string]string{"version": "1", "width": "2"}, false},
+		{"no migration from the current version", `{"version":1}`, saveVersion, nil, true},
+		{"not an object", `[1,2]`, 0, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			raw, err := migrateSave(json.RawMessage(tt.raw), tt.version)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("migrateSave() error = %v, want error %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			var fields map[string]json.RawMessage
+			if err := json.Unmarshal(raw, &fields); err != nil {
+				t.Fatal(err)
+			}
+			if len(fields) != len(tt.want) {
+				t.Errorf("migrated save has %d fields, want %d: %s", len(fields), len(tt.want), raw)
+			}
+			for k, v := range tt.want {
+				if string(fields[k]) != v {
+					t.Errorf("field %q = %s, want %s", k, fields[k], v)
+				}
+			}
+		})
+	}
+}
+
+func TestDeserializeBoardVersions(t *testing.T) {
+	b := boardFromTemplate(t, "M.\n..")
+	current, err := b.Serialize()
+	if err != nil {
+		t.Fatal(err)
+	}
+	newer := bytes.Replace(current, []byte(`"version":1`), []byte(`"version":2`), 1)
+	tests := []struct {
+		name string
+		data []byte
+		want error
+	}{
+		{"current version", current, nil},
+		{"newer version", newer, ErrInvalidSave},
+		{"not JSON", []byte("version 1"), ErrInvalidSave},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := DeserializeBoard(tt.data); !errors.Is(err, tt.want) {
+				t.Errorf("DeserializeBoard() = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompactReprLayout(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		play     func(b *Board)
+		cells    []byte
+	}{
+		{"fresh", "..\n..", func(b *Board) {}, []byte{0b00_00_00_00}},
+		{"one of each", "M.\n..", func(b *Board) { b.FlagCell(0, 0); b.RevealCell(1, 1) }, []byte{0b01_00_00_11}},
+		{"mine in the second byte", ".....\n....M", func(b *Board) {}, []byte{0, 0, 0b00_00_10_00}},
+		{"revealed number", "...\nM..", func(b *Board) { b.RevealCell(0, 0) }, []byte{0b10_00_00_01, 0}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := boardFromTemplate(t, tt.template)
+			tt.play(b)
+			data, err := b.CompactRepr()
+			if err != nil {
+				t.Fatal(err)
+			}
+			want := []byte{byte(b.Width), byte(b.Height)}
+			want = binary.BigEndian.AppendUint32(want, crc32.ChecksumIEEE(tt.cells))
+			want = append(want, tt.cells...)
+			if !bytes.Equal(data, want) {
+				t.Errorf("CompactRepr() = %08b, want %08b", data, want)
+			}
+		})
+	}
+}
+
+func TestCompactReprRoundTripDifficulties(t *testing.T) {
+	for _, d := range []Difficulty{DifficultyBeginner, DifficultyIntermediate, DifficultyExpert} {
+		width, height, mines := d.Params()
+		b := NewBoard(width, height, mines, WithSeed(1))
+		// Flag every other mine and reveal a scattering of safe cells
+		b.ForEachCell(func(x, y int, cell Cell) {
+			switch {
+			case cell.IsMine && (x+y)%2 == 0:
+				b.FlagCell(x, y)
+			case !cell.IsMine && (x*7+y*3)%11 == 0:
+				b.RevealCell(x, y)
+			}
+		})
+		data, err := b.CompactRepr()
+		if err != nil {
+			t.Fatalf("%s: CompactRepr: %v", d, err)
+		}
+		if want := (width*height+3)/4 + 6; len(data) != want {
+			t.Errorf("%s: CompactRepr is %d bytes, want %d", d, len(data), want)
+		}
+		var got Board
+		if err := got.FromCompactRepr(data); err != nil {
+			t.Fatalf("%s: FromCompactRepr: %v", d, err)
+		}
+		if got.TotalMines != b.TotalMines || got.State != b.State {
+			t.Errorf("%s: got %d mines, %v, want %d mines, %v", d, got.TotalMines, got.State, b.TotalMines, b.State)
+		}
+		b.ForEachCell(func(x, y int, cell Cell) {
+			if g := got.Cells[y][x]; g != cell {
+				t.Errorf("%s: cell (%d,%d) = %+v, want %+v", d, x, y, g, cell)
+			}
+		})
+	}
+}
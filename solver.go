@@ -0,0 +1,160 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ExplainMove returns a human-readable explanation of why revealing (x, y) is safe.
+// A reveal is explained by every revealed neighbor whose mines are all flagged already. Coordinates in the text are 1-based, as typed at the prompt.
+// If no such neighbor exists the move isn't provably safe and the explanation says it's a guess.
+func (b *Board) ExplainMove(x, y int) string {
+	const guess = "No logical deduction available; this is a guess."
+	if !b.isValidCell(x, y) || b.Cells[y][x].Revealed || b.Cells[y][x].Flagged {
+		return guess
+	}
+
+	var reasons []string
+	for _, n := range b.neighbors(x, y) {
+		neighbor := b.Cells[n[1]][n[0]]
+		if !neighbor.Revealed || neighbor.IsMine {
+			continue
+		}
+		if flags := b.AdjacentFlaggedCount(n[0], n[1]); flags == neighbor.AdjMines {
+			reasons = append(reasons, fmt.Sprintf("the constraint from (%d,%d) [mine count=%d, flags=%d] leaves no remaining mines among its neighbors", n[0]+1, n[1]+1, neighbor.AdjMines, flags))
+		}
+	}
+	if len(reasons) == 0 {
+		return guess
+	}
+	return fmt.Sprintf("Cell (%d,%d) is safe because %s.", x+1, y+1, strings.Join(reasons, ", and "))
+}
+
+// AllNeighborMines reports whether every unrevealed, unflagged neighbor of (x, y) must be a mine,
+// i.e. whether the mines not yet flagged (AdjMines - flagged neighbors) exactly fill the unrevealed, unflagged neighbors.
+// This is the auto-flag condition. It's only meaningful for a revealed number, and is trivially true when the count is already met
+// by flags and no unflagged neighbors are left, including for a cell with no neighbors at all.
+func (b *Board) AllNeighborMines(x, y int) bool {
+	if !b.isValidCell(x, y) {
+		return false
+	}
+	remaining := b.Cells[y][x].AdjMines - b.AdjacentFlaggedCount(x, y)
+	return remaining == b.AdjacentSafeUnrevealedCount(x, y)
+}
+
+// SolverStep struct is one deduction made by the solver
+// Action is CmdFlag or CmdReveal, Coord the 0-based cell it applies to, and Reason explains it with 1-based coordinates like ExplainMove.
+type SolverStep struct {
+	Action string
+	Coord  Point
+	Reason string
+}
+
+// SolverSteps plays the board out on a clone using single-number deductions, and returns each step in the order it was made.
+// A number whose remaining mines equal its unrevealed neighbors flags them, a number whose mines are all flagged reveals the rest.
+// The steps stop when no deduction is left, which is either a win or a point where the player has to guess.
+func (b *Board) SolverSteps() []SolverStep {
+	ghost := b.Clone()
+	var steps []SolverStep
+	for ghost.State == StatePlaying {
+		step, found := ghost.nextSolverStep()
+		if !found {
+			break
+		}
+		ghost.applySolverStep(step)
+		steps = append(steps, step)
+	}
+	return steps
+}
+
+// WinPath returns a sequence of moves that wins the board from its current state without guessing, played out on a clone.
+// It uses the single-number deductions of SolverSteps, and the safe cells found by SafeCells when those run out.
+// It returns the moves so far and false if the board can't be finished without a guess, or if the game is already lost.
+func (b *Board) WinPath() ([]Move, bool) {
+	ghost := b.Clone()
+	var moves []Move
+	for ghost.State == StatePlaying {
+		if step, found := ghost.nextSolverStep(); found {
+			ghost.applySolverStep(step)
+			moves = append(moves, Move{Cmd: step.Action, X: step.Coord.X, Y: step.Coord.Y})
+			continue
+		}
+		safe := ghost.SafeCells()
+		if len(safe) == 0 {
+			break
+		}
+		ghost.RevealCell(safe[0][0], safe[0][1])
+		moves = append(moves, Move{Cmd: CmdReveal, X: safe[0][0], Y: safe[0][1]})
+	}
+	return moves, ghost.State == StateWon || (ghost.State == StatePlaying && ghost.CheckWin())
+}
+
+// QuickSolveStep finds the first single-number deduction on the board like SolverSteps, and plays it on the board itself.
+// It returns the action taken (CmdFlag or CmdReveal) and the cell it was taken on, or found = false if no deduction is available.
+func (b *Board) QuickSolveStep() (action string, coord Point, found bool) {
+	if b.State != StatePlaying {
+		return "", Point{}, false
+	}
+	step, found := b.nextSolverStep()
+	if !found {
+		return "", Point{}, false
+	}
+	b.applySolverStep(step)
+	return step.Action, step.Coord, true
+}
+
+// nextSolverStep finds the first deduction available on the board, scanning the revealed numbers in row-major order.
+func (b *Board) nextSolverStep() (SolverStep, bool) {
+	for y := range b.Cells {
+		for x := range b.Cells[y] {
+			cell := b.Cells[y][x]
+			if !cell.Revealed || cell.IsMine || cell.AdjMines == 0 {
+				continue
+			}
+			unknown := b.AdjacentSafeUnrevealedCount(x, y)
+			if unknown == 0 {
+				continue
+			}
+			var target [2]int
+			for _, n := range b.neighbors(x, y) {
+				if c := b.Cells[n[1]][n[0]]; !c.Revealed && !c.Flagged {
+					target = n
+					break
+				}
+			}
+			coord := Point{X: target[0], Y: target[1]}
+
+			remaining := cell.AdjMines - b.AdjacentFlaggedCount(x, y)
+			switch {
+			case b.AllNeighborMines(x, y):
+				reason := fmt.Sprintf("Cell (%d,%d) has %s left and %d unrevealed neighbors", x+1, y+1, pluralMines(remaining), unknown)
+				if unknown == 1 {
+					reason = fmt.Sprintf("Cell (%d,%d) has %s, only neighbor (%d,%d) unrevealed", x+1, y+1, pluralMines(remaining), coord.X+1, coord.Y+1)
+				}
+				return SolverStep{Action: CmdFlag, Coord: coord, Reason: reason}, true
+			case remaining == 0:
+				reason := fmt.Sprintf("Cell (%d,%d) has %s, all flagged", x+1, y+1, pluralMines(cell.AdjMines))
+				return SolverStep{Action: CmdReveal, Coord: coord, Reason: reason}, true
+			}
+		}
+	}
+	return SolverStep{}, false
+}
+
+// applySolverStep plays a step found by nextSolverStep on the board.
+func (b *Board) applySolverStep(step SolverStep) {
+	switch step.Action {
+	case CmdFlag:
+		b.FlagCell(step.Coord.X, step.Coord.Y)
+	case CmdReveal:
+		b.RevealCell(step.Coord.X, step.Coord.Y)
+	}
+}
+
+// pluralMines formats a mine count, e.g. "1 mine" or "2 mines".
+func pluralMines(n int) string {
+	if n == 1 {
+		return "1 mine"
+	}
+	return fmt.Sprintf("%d mines", n)
+}
@@ -0,0 +1,214 @@
+package main
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestExplainMove(t *testing.T) {
+	const guess = "No logical deduction available; this is a guess."
+	tests := []struct {
+		name   string
+		reveal [][2]int
+		flag   [][2]int
+		x, y   int
+		want   string
+	}{
+		{"one constraint", [][2]int{{1, 0}}, [][2]int{{0, 0}}, 0, 1,
+			"Cell (1,2) is safe because the constraint from (2,1) [mine count=1, flags=1] leaves no remaining mines among its neighbors."},
+		{"two constraints", [][2]int{{1, 0}, {1, 1}}, [][2]int{{0, 0}}, 0, 1,
+			"Cell (1,2) is safe because the constraint from (2,1) [mine count=1, flags=1] leaves no remaining mines among its neighbors, " +
+				"and the constraint from (2,2) [mine count=1, flags=1] leaves no remaining mines among its neighbors."},
+		{"mine not flagged yet", [][2]int{{1, 0}}, nil, 0, 1, guess},
+		{"nothing revealed", nil, nil, 0, 1, guess},
+		{"revealed cell", [][2]int{{1, 0}}, [][2]int{{0, 0}}, 1, 0, guess},
+		{"flagged cell", [][2]int{{1, 0}}, [][2]int{{0, 0}}, 0, 0, guess},
+		{"off the board", nil, nil, 7, 0, guess},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := boardFromTemplate(t, "M...\n....\n...M")
+			for _, c := range tt.reveal {
+				b.RevealCell(c[0], c[1])
+			}
+			for _, c := range tt.flag {
+				b.FlagCell(c[0], c[1])
+			}
+			if got := b.ExplainMove(tt.x, tt.y); got != tt.want {
+				t.Errorf("ExplainMove(%d, %d) =\n%q\nwant\n%q", tt.x, tt.y, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSolverSteps(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		start    [2]int
+		want     []SolverStep
+	}{
+		{"fully determined", "M...\nM...\n....", [2]int{2, 0}, []SolverStep{
+			{CmdFlag, Point{0, 0}, "Cell (2,1) has 2 mines left and 2 unrevealed neighbors"},
+			{CmdFlag, Point{0, 1}, "Cell (2,1) has 1 mine, only neighbor (1,2) unrevealed"},
+			{CmdReveal, Point{0, 2}, "Cell (2,2) has 2 mines, all flagged"},
+		}},
+		{"flags then reveals", "..MM\n....\nM...", [2]int{2, 2}, []SolverStep{
+			{CmdFlag, Point{2, 0}, "Cell (4,2) has 2 mines left and 2 unrevealed neighbors"},
+			{CmdFlag, Point{3, 0}, "Cell (4,2) has 1 mine, only neighbor (4,1) unrevealed"},
+			{CmdReveal, Point{1, 0}, "Cell (3,2) has 2 mines, all flagged"},
+			{CmdReveal, Point{0, 0}, "Cell (2,1) has 1 mine, all flagged"},
+		}},
+		{"needs a guess", ".M.\n...\n...", [2]int{0, 2}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := boardFromTemplate(t, tt.template)
+			b.RevealCell(tt.start[0], tt.start[1])
+			revealed := b.CountRevealed()
+
+			got := b.SolverSteps()
+			if len(got) != len(tt.want) {
+				t.Fatalf("SolverSteps() returned %d steps, want %d: %+v", len(got), len(tt.want), got)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("step %d = %+v\nwant %+v", i, got[i], tt.want[i])
+				}
+			}
+			if b.CountRevealed() != revealed || b.CountFlags() != 0 {
+				t.Error("SolverSteps changed the board it was called on")
+			}
+		})
+	}
+}
+
+func TestSolverStepsWinBoard(t *testing.T) {
+	b := boardFromTemplate(t, "M...\nM...\n....")
+	b.RevealCell(2, 0)
+	for _, step := range b.SolverSteps() {
+		b.applySolverStep(step)
+	}
+	if b.State != StateWon {
+		t.Errorf("State after playing every step = %v, want StateWon", b.State)
+	}
+}
+
+func TestAllNeighborMines(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		reveal   [][2]int
+		flag     [][2]int
+		x, y     int
+		want     bool
+	}{
+		{"no neighbors", ".", [][2]int{{0, 0}}, nil, 0, 0, true},
+		{"all unrevealed are mines", "M.\n..", [][2]int{{1, 0}, {0, 1}, {1, 1}}, nil, 1, 0, true},
+		{"all mines flagged", "M.\n..\n..", [][2]int{{1, 0}, {0, 1}, {1, 1}}, [][2]int{{0, 0}}, 1, 0, true},
+		{"partial match", "M.\n..", [][2]int{{1, 0}, {0, 1}}, nil, 1, 0, false},
+		{"nothing revealed around", "M.\n..", [][2]int{{1, 0}}, nil, 1, 0, false},
+		{"one of two flagged, one left", "MM\n..", [][2]int{{0, 1}, {1, 1}}, [][2]int{{0, 0}}, 0, 1, true},
+		{"one of two flagged, two left", "MM\n..", [][2]int{{0, 1}}, [][2]int{{0, 0}}, 0, 1, false},
+		{"wrong flag", "M.\n..", [][2]int{{1, 0}, {0, 1}}, [][2]int{{1, 1}}, 1, 0, false},
+		{"off the board", "M.", nil, nil, 2, 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := boardFromTemplate(t, tt.template)
+			for _, c := range tt.reveal {
+				b.RevealCell(c[0], c[1])
+			}
+			for _, c := range tt.flag {
+				b.FlagCell(c[0], c[1])
+			}
+			if got := b.AllNeighborMines(tt.x, tt.y); got != tt.want {
+				t.Errorf("AllNeighborMines(%d, %d) = %v, want %v", tt.x, tt.y, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestQuickSolveStep(t *testing.T) {
+	type step struct {
+		action string
+		coord  Point
+		found  bool
+	}
+	tests := []struct {
+		name     string
+		template string
+		start    [2]int
+		want     []step
+		state    GameState
+	}{
+		{"solves the board", "M...\nM...\n....", [2]int{2, 0}, []step{
+			{CmdFlag, Point{0, 0}, true},
+			{CmdFlag, Point{0, 1}, true},
+			{CmdReveal, Point{0, 2}, true},
+			{"", Point{}, false},
+		}, StateWon},
+		{"stops at a guess", ".M.\n...\n...", [2]int{0, 2}, []step{
+			{"", Point{}, false},
+		}, StatePlaying},
+		{"game over", "M..\n...", [2]int{0, 0}, []step{
+			{"", Point{}, false},
+		}, StateLost},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := boardFromTemplate(t, tt.template)
+			b.RevealCell(tt.start[0], tt.start[1])
+			for i, want := range tt.want {
+				action, coord, found := b.QuickSolveStep()
+				if got := (step{action, coord, found}); got != want {
+					t.Errorf("call %d = %+v, want %+v", i+1, got, want)
+				}
+			}
+			if b.State != tt.state {
+				t.Errorf("State = %v, want %v", b.State, tt.state)
+			}
+		})
+	}
+}
+
+func TestWinPath(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		start    [][2]int
+		ok       bool
+	}{
+		{"single-number deductions", "M...\nM...\n....", [][2]int{{2, 0}}, true},
+		{"flags and reveals", "..MM\n....\nM...", [][2]int{{2, 2}}, true},
+		{"already won", "M..", [][2]int{{2, 0}}, true},
+		{"needs a guess", ".M.\n...\n...", [][2]int{{0, 2}}, false},
+		{"nothing revealed", "M...\n....", nil, false},
+		{"lost", "M...\n....", [][2]int{{0, 0}}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := boardFromTemplate(t, tt.template)
+			for _, c := range tt.start {
+				b.RevealCell(c[0], c[1])
+			}
+			before := b.Clone()
+			moves, ok := b.WinPath()
+			if ok != tt.ok {
+				t.Fatalf("WinPath() = %v, %v, want ok = %v", moves, ok, tt.ok)
+			}
+			if !slices.EqualFunc(before.Cells, b.Cells, slices.Equal[[]Cell]) {
+				t.Error("WinPath changed the board")
+			}
+			if !ok {
+				return
+			}
+			if err := b.ApplyMoves(moves); err != nil {
+				t.Fatalf("ApplyMoves: %v", err)
+			}
+			if !b.CheckWin() || b.MineRevealed {
+				t.Errorf("applying %v didn't win the board", moves)
+			}
+		})
+	}
+}
@@ -0,0 +1,242 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// Compute3BV computes the board's 3BV (Bechtel's Board Benchmark Value), the minimum number of clicks needed to clear the board without flags.
+// Every opening (a connected region of zero-adj cells, together with its numbered border) counts as one click,
+// and every numbered cell that doesn't border an opening needs a click of its own.
+func (b *Board) Compute3BV() int {
+	return b.count3BV(false)
+}
+
+// MinSolvingMoves returns the fewest clicks needed to finish the board from its current state, the 3BV of what is left.
+// An opening counts only while none of its zero cells has been revealed, and a numbered cell outside every opening only while it is hidden.
+// On a fresh board it equals Compute3BV, and it goes down as the game progresses.
+func (b *Board) MinSolvingMoves() int {
+	return b.count3BV(true)
+}
+
+// count3BV does the work for Compute3BV, and for MinSolvingMoves when current is true, skipping what has already been revealed.
+func (b *Board) count3BV(current bool) int {
+	marked := make([][]bool, b.Height)
+	for i := range marked {
+		marked[i] = make([]bool, b.Width)
+	}
+
+	count := 0
+	// First pass: flood fill each opening, marking it and its border
+	for y := 0; y < b.Height; y++ {
+		for x := 0; x < b.Width; x++ {
+			cell := b.Cells[y][x]
+			if marked[y][x] || cell.IsMine || cell.AdjMines != 0 {
+				continue
+			}
+			opened := false
+			queue := [][2]int{{x, y}}
+			marked[y][x] = true
+			for len(queue) > 0 {
+				cx, cy := queue[0][0], queue[0][1]
+				queue = queue[1:]
+				opened = opened || b.Cells[cy][cx].Revealed
+				for i := -1; i <= 1; i++ {
+					for j := -1; j <= 1; j++ {
+						nx, ny := cx+i, cy+j
+						if !b.isValidCell(nx, ny) || marked[ny][nx] || b.Cells[ny][nx].IsMine {
+							continue
+						}
+						marked[ny][nx] = true
+						if b.Cells[ny][nx].AdjMines == 0 {
+							queue = append(queue, [2]int{nx, ny})
+						}
+					}
+				}
+			}
+			if !current || !opened {
+				count++
+			}
+		}
+	}
+
+	// Second pass: every numbered cell left over needs its own click
+	for y := 0; y < b.Height; y++ {
+		for x := 0; x < b.Width; x++ {
+			if !marked[y][x] && !b.Cells[y][x].IsMine && (!current || !b.Cells[y][x].Revealed) {
+				count++
+			}
+		}
+	}
+	return count
+}
+
+// UncoveredFraction returns the fraction of safe cells that have been revealed, between 0 and 1.
+func (b *Board) UncoveredFraction() float64 {
+	safe := b.CountCellsWhere(func(x, y int, cell Cell) bool { return !cell.IsMine })
+	revealed := b.CountCellsWhere(func(x, y int, cell Cell) bool { return !cell.IsMine && cell.Revealed })
+	if safe == 0 {
+		return 1
+	}
+	return float64(revealed) / float64(safe)
+}
+
+// ProgressPercent returns UncoveredFraction as a whole percentage from 0 to 100, for status bars.
+func (b *Board) ProgressPercent() int {
+	return min(max(int(b.UncoveredFraction()*100), 0), 100)
+}
+
+// ProgressBar returns an ASCII progress bar with width characters between the brackets, followed by the percentage, e.g. "[=====>    ] 55%".
+func (b *Board) ProgressBar(width int) string {
+	percent := b.ProgressPercent()
+	width = max(width, 0)
+	filled := width * percent / 100
+	bar := strings.Repeat("=", filled)
+	if filled < width {
+		bar += ">" + strings.Repeat(" ", width-filled-1)
+	}
+	return fmt.Sprintf("[%s] %d%%", bar, percent)
+}
+
+// RowEntropy returns the binary entropy of the mine fraction of every row, -p*log2(p) - (1-p)*log2(1-p) where p is mines / Width.
+// A row of only mines or only safe cells scores 0, a row that is half mines scores 1.
+func (b *Board) RowEntropy() []float64 {
+	entropy := make([]float64, b.Height)
+	for y, row := range b.Cells {
+		mines := 0
+		for _, cell := range row {
+			if cell.IsMine {
+				mines++
+			}
+		}
+		entropy[y] = binaryEntropy(mines, b.Width)
+	}
+	return entropy
+}
+
+// ColEntropy is RowEntropy for the columns, with p as mines / Height.
+func (b *Board) ColEntropy() []float64 {
+	mines := make([]int, b.Width)
+	b.ForEachCell(func(x, y int, cell Cell) {
+		if cell.IsMine {
+			mines[x]++
+		}
+	})
+	entropy := make([]float64, b.Width)
+	for x, n := range mines {
+		entropy[x] = binaryEntropy(n, b.Height)
+	}
+	return entropy
+}
+
+// binaryEntropy returns the entropy in bits of picking a mine with probability mines / total. 0*log2(0) is taken as 0.
+func binaryEntropy(mines, total int) float64 {
+	if mines <= 0 || mines >= total {
+		return 0
+	}
+	p := float64(mines) / float64(total)
+	return -p*math.Log2(p) - (1-p)*math.Log2(1-p)
+}
+
+// MineEdgeDensity returns the fraction of the board's mines that lie on its outer ring, between 0 and 1.
+// Edge mines have fewer neighbors to give them away, but they also leave the middle of the board open. It returns 0 if the board has no mines.
+func (b *Board) MineEdgeDensity() float64 {
+	if b.TotalMines == 0 {
+		return 0
+	}
+	return float64(b.PerimeterMineCount()) / float64(b.TotalMines)
+}
+
+// MineDistribution returns a histogram of the adjacency counts of the non-mine cells, mapping each count from 0 to 8 to the number of cells with it.
+// Counts that don't occur are left out, so the values sum to the number of safe cells.
+func (b *Board) MineDistribution() map[int]int {
+	dist := make(map[int]int)
+	b.ForEachCell(func(x, y int, cell Cell) {
+		if !cell.IsMine {
+			dist[cell.AdjMines]++
+		}
+	})
+	return dist
+}
+
+// MineCluster returns the fraction of mines that touch at least one other mine, between 0 and 1.
+// Clustered mines hide behind each other, so higher means harder. It returns 0 if the board has no mines.
+func (b *Board) MineCluster() float64 {
+	mines := b.CountMines()
+	if mines == 0 {
+		return 0
+	}
+	clustered := b.CountCellsWhere(func(x, y int, cell Cell) bool {
+		if !cell.IsMine {
+			return false
+		}
+		for _, n := range b.neighbors(x, y) {
+			if b.Cells[n[1]][n[0]].IsMine {
+				return true
+			}
+		}
+		return false
+	})
+	return float64(clustered) / float64(mines)
+}
+
+// IslandCount returns the number of openings on the board, as found by OpeningChains.
+func (b *Board) IslandCount() int {
+	return len(b.OpeningChains())
+}
+
+// MaxOpeningSize returns the number of cells in the largest opening, i.e. the most a single click can reveal. It is 0 if there are no openings.
+func (b *Board) MaxOpeningSize() int {
+	size := 0
+	for _, chain := range b.OpeningChains() {
+		size = max(size, len(chain))
+	}
+	return size
+}
+
+// ClusterScore combines MineCluster, IslandCount and MaxOpeningSize into a difficulty proxy between 0 and 1, higher is harder:
+// MineCluster*0.4 + (1/IslandCount)*0.3 + (1/MaxOpeningSize)*0.3. A board without openings takes the full 0.3 for both opening terms.
+// It's cheap enough to sort generated boards by difficulty without running the solver.
+func (b *Board) ClusterScore() float64 {
+	score := b.MineCluster() * 0.4
+	if islands := b.IslandCount(); islands > 0 {
+		score += 0.3/float64(islands) + 0.3/float64(b.MaxOpeningSize())
+	} else {
+		score += 0.6
+	}
+	return score
+}
+
+// ZeroAdjCellCount returns the number of safe cells with no adjacent mines, the cells that set off a flood fill when revealed.
+func (b *Board) ZeroAdjCellCount() int {
+	return b.CountCellsWhere(func(x, y int, cell Cell) bool { return !cell.IsMine && cell.AdjMines == 0 })
+}
+
+// MineCountInRegion returns the number of mines in the w x h rectangle whose top-left corner is (x, y).
+// It returns a MinesweeperError wrapping ErrOutOfBounds if the rectangle is empty or doesn't fit on the board.
+func (b *Board) MineCountInRegion(x, y, w, h int) (int, error) {
+	grid := b.SubGrid(x, y, w, h)
+	if grid == nil {
+		return 0, cellError("MineCountInRegion", x, y, ErrOutOfBounds)
+	}
+	count := 0
+	for _, row := range grid {
+		for _, cell := range row {
+			if cell.IsMine {
+				count++
+			}
+		}
+	}
+	return count, nil
+}
+
+// PerimeterMineCount returns the number of mines on the outer ring of the board.
+func (b *Board) PerimeterMineCount() int {
+	return b.CountCellsWhere(func(x, y int, cell Cell) bool { return cell.IsMine && b.isEdgeCell(x, y) })
+}
+
+// InteriorMineCount returns the number of mines strictly inside the outer ring. Boards narrower or shorter than 3 have no interior.
+func (b *Board) InteriorMineCount() int {
+	return b.CountCellsWhere(func(x, y int, cell Cell) bool { return cell.IsMine && !b.isEdgeCell(x, y) })
+}
@@ -0,0 +1,353 @@
+package main
+
+import (
+	"errors"
+	"maps"
+	"math"
+	"strings"
+	"testing"
+)
+
+// progressBoard returns a 21x1 board with a mine in the first cell and the first revealed safe cells revealed, 5% each.
+func progressBoard(t *testing.T, revealed int) *Board {
+	t.Helper()
+	b := boardFromTemplate(t, "M"+strings.Repeat(".", 20))
+	for x := 1; x <= revealed; x++ {
+		b.Cells[0][x].Revealed = true
+	}
+	return b
+}
+
+func TestProgressPercent(t *testing.T) {
+	tests := []struct {
+		name     string
+		revealed int
+		want     int
+	}{
+		{"fresh board", 0, 0},
+		{"one cell", 1, 5},
+		{"just over half", 11, 55},
+		{"all safe cells", 20, 100},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := progressBoard(t, tt.revealed).ProgressPercent(); got != tt.want {
+				t.Errorf("ProgressPercent() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+
+	won := boardFromTemplate(t, "M..\n...")
+	won.RevealCell(2, 1)
+	won.RevealCell(0, 1)
+	if won.State != StateWon || won.ProgressPercent() != 100 {
+		t.Errorf("won game: State = %v, ProgressPercent() = %d, want StateWon and 100", won.State, won.ProgressPercent())
+	}
+}
+
+func TestProgressBar(t *testing.T) {
+	tests := []struct {
+		revealed int
+		width    int
+		want     string
+	}{
+		{0, 10, "[>         ] 0%"},
+		{11, 10, "[=====>    ] 55%"},
+		{10, 4, "[==> ] 50%"},
+		{19, 10, "[=========>] 95%"},
+		{20, 10, "[==========] 100%"},
+		{20, 0, "[] 100%"},
+		{5, 0, "[] 25%"},
+	}
+	for _, tt := range tests {
+		b := progressBoard(t, tt.revealed)
+		got := b.ProgressBar(tt.width)
+		if got != tt.want {
+			t.Errorf("ProgressBar(%d) at %d%% = %q, want %q", tt.width, b.ProgressPercent(), got, tt.want)
+		}
+		if bar := got[1:strings.Index(got, "]")]; len(bar) != tt.width {
+			t.Errorf("ProgressBar(%d) bar is %d characters", tt.width, len(bar))
+		}
+	}
+}
+
+func TestRowColEntropy(t *testing.T) {
+	const quarter = 0.8112781244591328 // H(1/4) = H(3/4)
+	tests := []struct {
+		name     string
+		template string
+		rows     []float64
+		cols     []float64
+	}{
+		{"mixed", "MMMM\n....\nMM..\nM...", []float64{0, 0, 1, quarter}, []float64{quarter, 1, quarter, quarter}},
+		{"no mines", "...\n...", []float64{0, 0}, []float64{0, 0, 0}},
+		{"all mines", "MM\nMM", []float64{0, 0}, []float64{0, 0}},
+		{"checkerboard", "M.\n.M", []float64{1, 1}, []float64{1, 1}},
+	}
+	equal := func(a, b []float64) bool {
+		if len(a) != len(b) {
+			return false
+		}
+		for i := range a {
+			if math.Abs(a[i]-b[i]) > 1e-9 {
+				return false
+			}
+		}
+		return true
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := boardFromTemplate(t, tt.template)
+			if got := b.RowEntropy(); !equal(got, tt.rows) {
+				t.Errorf("RowEntropy() = %v, want %v", got, tt.rows)
+			}
+			if got := b.ColEntropy(); !equal(got, tt.cols) {
+				t.Errorf("ColEntropy() = %v, want %v", got, tt.cols)
+			}
+		})
+	}
+}
+
+func TestMineEdgeDensity(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		want     float64
+	}{
+		{"all on the border", "M..M\n....\n....\nM.M.", 1},
+		{"all interior", "....\n.MM.\n.MM.\n....", 0},
+		{"half", "M...\n.M..\n....\n....", 0.5},
+		{"a third", "M...\n.MM.\n....\n....", 1.0 / 3},
+		{"single row is all border", ".M.M.", 1},
+		{"no mines", "...\n...\n...", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := boardFromTemplate(t, tt.template).MineEdgeDensity(); math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("MineEdgeDensity() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMineDistribution(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		want     map[int]int
+	}{
+		{"single mine", "...\n.M.\n...", map[int]int{1: 8}},
+		{"corner mine", "M..\n...\n...", map[int]int{0: 5, 1: 3}},
+		{"surrounded cell", "MMM\nM.M\nMMM", map[int]int{8: 1}},
+		{"no mines", "..\n..", map[int]int{0: 4}},
+		{"all mines", "MM", map[int]int{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := boardFromTemplate(t, tt.template).MineDistribution()
+			if !maps.Equal(got, tt.want) {
+				t.Errorf("MineDistribution() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMineDistributionSums(t *testing.T) {
+	for _, d := range []Difficulty{DifficultyBeginner, DifficultyIntermediate, DifficultyExpert} {
+		b := NewBoard(d.Params())
+		total := 0
+		for count, cells := range b.MineDistribution() {
+			if count < 0 || count > 8 {
+				t.Errorf("%s board has adjacency count %d", d, count)
+			}
+			total += cells
+		}
+		if want := b.Width*b.Height - b.TotalMines; total != want {
+			t.Errorf("%s distribution sums to %d, want %d", d, total, want)
+		}
+	}
+}
+
+func TestMinSolvingMoves(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		reveal   [][2]int
+		want     []int // after each reveal, starting with the fresh board
+	}{
+		{"two openings", "..M..", [][2]int{{0, 0}, {4, 0}}, []int{2, 1, 0}},
+		{"border number of an unopened opening", "..M..", [][2]int{{1, 0}, {0, 0}}, []int{2, 2, 1}},
+		{"no openings", "M.M.M\n.....", [][2]int{{1, 0}, {3, 0}, {0, 1}, {1, 1}}, []int{7, 6, 5, 4, 3}},
+		{"opening and lone numbers", "....\n....\n..M.\n.M..", [][2]int{{0, 3}, {3, 0}, {2, 3}, {3, 2}, {3, 3}}, []int{5, 4, 3, 2, 1, 0}},
+		{"revealing twice", "M.M.M\n.....", [][2]int{{1, 0}, {1, 0}}, []int{7, 6, 6}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := boardFromTemplate(t, tt.template)
+			if got := b.MinSolvingMoves(); got != b.Compute3BV() || got != tt.want[0] {
+				t.Errorf("fresh board: MinSolvingMoves() = %d, Compute3BV() = %d, want %d", got, b.Compute3BV(), tt.want[0])
+			}
+			for i, c := range tt.reveal {
+				b.RevealCell(c[0], c[1])
+				if got := b.MinSolvingMoves(); got != tt.want[i+1] {
+					t.Errorf("after revealing %v: MinSolvingMoves() = %d, want %d", c, got, tt.want[i+1])
+				}
+			}
+		})
+	}
+}
+
+func TestClusterScore(t *testing.T) {
+	// Ordered from easiest to hardest: more openings and lone mines score
+	// lower, clustered mines and boards without openings score higher
+	tests := []struct {
+		name     string
+		template string
+		want     float64
+	}{
+		{"lone mines and two openings", "M..M....\n........\n.....M..\n........", 0.4*0 + 0.3/2 + 0.3/15},
+		{"one lone mine", "M.......\n........\n........\n........", 0.3/1 + 0.3/31},
+		{"lone mines without openings", "M.M.M.M.\n........\nM.M.M.M.\n........", 0.6},
+		{"paired mines", "MM......\n........\n......MM\n........", 0.4 + 0.3/1 + 0.3/26},
+		{"one cluster without openings", "MM.\nMM.\n...", 0.4 + 0.6},
+	}
+	prev := -1.0
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := boardFromTemplate(t, tt.template).ClusterScore()
+			if math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("ClusterScore() = %v, want %v", got, tt.want)
+			}
+			if got < 0 || got > 1 {
+				t.Errorf("ClusterScore() = %v, want a value in [0, 1]", got)
+			}
+			if got <= prev {
+				t.Errorf("ClusterScore() = %v, want more than the easier board's %v", got, prev)
+			}
+			prev = got
+		})
+	}
+}
+
+func TestZeroAdjCellCount(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		want     int
+	}{
+		{"no mines", "....\n....\n....", 12},
+		{"every safe cell touches a mine", "...\n.M.\n...", 0},
+		{"checkerboard", "M.M\n.M.\nM.M", 0},
+		{"corner mine", "M...\n....\n....", 8},
+		{"centre of a 5x5", ".....\n.....\n..M..\n.....\n.....", 16},
+		{"all mines", "MM\nMM", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := boardFromTemplate(t, tt.template).ZeroAdjCellCount(); got != tt.want {
+				t.Errorf("ZeroAdjCellCount() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestZeroAdjCellCountRange(t *testing.T) {
+	for _, d := range []Difficulty{DifficultyBeginner, DifficultyIntermediate, DifficultyExpert} {
+		b := NewBoard(d.Params())
+		if got, safe := b.ZeroAdjCellCount(), b.Width*b.Height-b.TotalMines; got <= 0 || got >= safe {
+			t.Errorf("%s board has %d zero cells out of %d safe cells", d, got, safe)
+		}
+	}
+}
+
+func TestMineCountInRegion(t *testing.T) {
+	const template = `
+M..M.
+.M...
+..MM.
+M...M
+`
+	tests := []struct {
+		name       string
+		x, y, w, h int
+		want       int
+		wantErr    bool
+	}{
+		{"whole board", 0, 0, 5, 4, 7, false},
+		{"single mine", 0, 0, 1, 1, 1, false},
+		{"single safe cell", 1, 0, 1, 1, 0, false},
+		{"top-left corner", 0, 0, 2, 2, 2, false},
+		{"bottom-right corner", 3, 2, 2, 2, 2, false},
+		{"interior", 1, 1, 3, 2, 3, false},
+		{"top row", 0, 0, 5, 1, 2, false},
+		{"right column", 4, 0, 1, 4, 1, false},
+		{"empty region of the board", 1, 3, 3, 1, 0, false},
+		{"past the right edge", 3, 0, 3, 1, 0, true},
+		{"past the bottom edge", 0, 3, 1, 2, 0, true},
+		{"negative corner", -1, 0, 2, 2, 0, true},
+		{"zero width", 0, 0, 0, 2, 0, true},
+		{"negative height", 0, 0, 2, -1, 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := boardFromTemplate(t, template)
+			got, err := b.MineCountInRegion(tt.x, tt.y, tt.w, tt.h)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("MineCountInRegion(%d, %d, %d, %d) error = %v, want error %v", tt.x, tt.y, tt.w, tt.h, err, tt.wantErr)
+			}
+			if err != nil {
+				if !errors.Is(err, ErrOutOfBounds) {
+					t.Errorf("error = %v, want ErrOutOfBounds", err)
+				}
+				return
+			}
+			// Check against a plain count of the rectangle too
+			manual := b.CountCellsWhere(func(x, y int, cell Cell) bool {
+				return cell.IsMine && x >= tt.x && x < tt.x+tt.w && y >= tt.y && y < tt.y+tt.h
+			})
+			if got != tt.want || got != manual {
+				t.Errorf("MineCountInRegion(%d, %d, %d, %d) = %d, counted %d, want %d", tt.x, tt.y, tt.w, tt.h, got, manual, tt.want)
+			}
+		})
+	}
+}
+
+func TestPerimeterAndInteriorMineCount(t *testing.T) {
+	tests := []struct {
+		name      string
+		template  string
+		perimeter int
+		interior  int
+	}{
+		{"no mines", "...\n...\n...", 0, 0},
+		{"centre mine", "...\n.M.\n...", 0, 1},
+		{"ring of mines", "MMM\nM.M\nMMM", 8, 0},
+		{"mixed", "M...\n.M..\n..M.\n...M", 2, 2},
+		{"2x2 has no interior", "MM\nM.", 3, 0},
+		{"single row has no interior", ".M.M.", 2, 0},
+		{"single column has no interior", "M\n.\nM", 2, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := boardFromTemplate(t, tt.template)
+			if got := b.PerimeterMineCount(); got != tt.perimeter {
+				t.Errorf("PerimeterMineCount() = %d, want %d", got, tt.perimeter)
+			}
+			if got := b.InteriorMineCount(); got != tt.interior {
+				t.Errorf("InteriorMineCount() = %d, want %d", got, tt.interior)
+			}
+		})
+	}
+}
+
+func TestPerimeterAndInteriorMineCountSum(t *testing.T) {
+	for _, d := range []Difficulty{DifficultyBeginner, DifficultyIntermediate, DifficultyExpert} {
+		for seed := int64(1); seed <= 5; seed++ {
+			width, height, mines := d.Params()
+			b := NewBoard(width, height, mines, WithSeed(seed))
+			if p, i := b.PerimeterMineCount(), b.InteriorMineCount(); p+i != b.CountMines() {
+				t.Errorf("%s seed %d: %d perimeter + %d interior mines, want %d", d, seed, p, i, b.CountMines())
+			}
+		}
+	}
+}
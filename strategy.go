@@ -0,0 +1,325 @@
+package main
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+)
+
+// CornerStrategy returns candidate first moves based on the corner heuristic.
+// Corners only have 3 neighbors, so they are less likely to be next to many mines than any other cell.
+// The unrevealed corners are returned first. Once every corner has been opened, the unrevealed outer-edge cells are returned instead.
+// On a 1x1 board the single cell is every corner at once, so duplicates are skipped.
+func (b *Board) CornerStrategy() [][2]int {
+	corners := [][2]int{
+		{0, 0},
+		{b.Width - 1, 0},
+		{0, b.Height - 1},
+		{b.Width - 1, b.Height - 1},
+	}
+
+	seen := make(map[[2]int]bool)
+	var result [][2]int
+	for _, c := range corners {
+		if seen[c] || !b.isValidCell(c[0], c[1]) || b.Cells[c[1]][c[0]].Revealed {
+			continue
+		}
+		seen[c] = true
+		result = append(result, c)
+	}
+	if len(result) > 0 {
+		return result
+	}
+
+	// All corners are open, fall back to the rest of the outer ring
+	for y := 0; y < b.Height; y++ {
+		for x := 0; x < b.Width; x++ {
+			if !b.isEdgeCell(x, y) || b.Cells[y][x].Revealed {
+				continue
+			}
+			result = append(result, [2]int{x, y})
+		}
+	}
+	return result
+}
+
+// RandomOpening picks a first move uniformly at random from CornerStrategy, which falls back to the edge cells,
+// or from every unrevealed cell if the whole outer ring is open. Pass a seeded r for reproducible picks.
+// It returns Point{-1, -1} if every cell is revealed.
+func (b *Board) RandomOpening(r *rand.Rand) Point {
+	candidates := b.CornerStrategy()
+	if len(candidates) == 0 {
+		candidates = b.FilterCells(func(x, y int, cell Cell) bool { return !cell.Revealed })
+	}
+	if len(candidates) == 0 {
+		return Point{X: -1, Y: -1}
+	}
+	c := candidates[r.Intn(len(candidates))]
+	return Point{X: c[0], Y: c[1]}
+}
+
+// isEdgeCell checks if the given coordinates lie on the outer ring of the board.
+func (b *Board) isEdgeCell(x, y int) bool {
+	return x == 0 || y == 0 || x == b.Width-1 || y == b.Height-1
+}
+
+// GhostReveal returns how many cells revealing (x, y) would open, without changing the board.
+// The reveal is played out on a clone, so the count includes the whole flood fill (and is 1 for a mine).
+func (b *Board) GhostReveal(x, y int) (newlyRevealedCount int) {
+	ghost := b.Clone()
+	before := ghost.CountRevealed()
+	ghost.RevealCell(x, y)
+	return ghost.CountRevealed() - before
+}
+
+// LayeredReveal returns the cells revealing (x, y) would open, grouped by BFS level, without changing the board.
+// result[0] holds (x, y) itself, result[1] the cells its flood fill opens next, and so on, for at most layers levels.
+// Each cell appears in exactly one layer, so applying the layers in order reproduces RevealCell. It returns nil if (x, y) is not an unrevealed cell.
+func (b *Board) LayeredReveal(x, y int, layers int) [][]Point {
+	if layers <= 0 || !b.isValidCell(x, y) || b.Cells[y][x].Revealed {
+		return nil
+	}
+	seen := map[[2]int]bool{{x, y}: true}
+	result := [][]Point{{{X: x, Y: y}}}
+	if b.Cells[y][x].IsMine {
+		return result
+	}
+	for len(result) < layers {
+		var next []Point
+		for _, p := range result[len(result)-1] {
+			if b.Cells[p.Y][p.X].AdjMines != 0 {
+				continue
+			}
+			for _, n := range b.neighbors(p.X, p.Y) {
+				if seen[n] || b.Cells[n[1]][n[0]].Revealed {
+					continue
+				}
+				seen[n] = true
+				next = append(next, Point{X: n[0], Y: n[1]})
+			}
+		}
+		if len(next) == 0 {
+			break
+		}
+		result = append(result, next)
+	}
+	return result
+}
+
+// SpiralReveal returns every cell on the board in an outward spiral from (startX, startY), without changing the board.
+// Cells are ordered by Chebyshev distance from the start, and cells at the same distance clockwise from north.
+// It returns nil if the start is not on the board.
+func (b *Board) SpiralReveal(startX, startY int) []Point {
+	if !b.isValidCell(startX, startY) {
+		return nil
+	}
+	angle := func(p Point) float64 {
+		// y grows downwards, so north is -y
+		a := math.Atan2(float64(p.X-startX), float64(startY-p.Y))
+		if a < 0 {
+			a += 2 * math.Pi
+		}
+		return a
+	}
+	points := make([]Point, 0, b.Width*b.Height)
+	b.ForEachCell(func(x, y int, cell Cell) {
+		points = append(points, Point{X: x, Y: y})
+	})
+	sort.SliceStable(points, func(i, j int) bool {
+		di := max(abs(points[i].X-startX), abs(points[i].Y-startY))
+		dj := max(abs(points[j].X-startX), abs(points[j].Y-startY))
+		if di != dj {
+			return di < dj
+		}
+		return angle(points[i]) < angle(points[j])
+	})
+	return points
+}
+
+// RandomWalkReveal reveals cells along a random walk from (startX, startY), for generating partly played boards.
+// The start is revealed first if it's hidden, then each step moves to a random hidden, unflagged, safe neighbor and reveals it, without any flood fill.
+// The walk stops after steps moves or when it is boxed in. It returns the cells it revealed in order, or nil if the start is off the board or a mine.
+func (b *Board) RandomWalkReveal(startX, startY int, steps int, r *rand.Rand) []Point {
+	if !b.isValidCell(startX, startY) || b.Cells[startY][startX].IsMine {
+		return nil
+	}
+	var walk []Point
+	if !b.Cells[startY][startX].Revealed {
+		b.Cells[startY][startX].Revealed = true
+		walk = append(walk, Point{X: startX, Y: startY})
+	}
+	x, y := startX, startY
+	for i := 0; i < steps; i++ {
+		var options [][2]int
+		for _, n := range b.neighbors(x, y) {
+			if c := b.Cells[n[1]][n[0]]; !c.Revealed && !c.Flagged && !c.IsMine {
+				options = append(options, n)
+			}
+		}
+		if len(options) == 0 {
+			break
+		}
+		next := options[r.Intn(len(options))]
+		x, y = next[0], next[1]
+		b.Cells[y][x].Revealed = true
+		walk = append(walk, Point{X: x, Y: y})
+	}
+	if b.CheckWin() {
+		b.endGame(StateWon)
+	}
+	return walk
+}
+
+// OpeningScore returns the expected number of cells a first safe click opens, i.e. the average GhostReveal over all non-mine cells.
+// A mine-free board scores Width*Height, a board where every safe cell touches a mine scores 1.
+func (b *Board) OpeningScore() float64 {
+	total, safe := 0, 0
+	b.ForEachCell(func(x, y int, cell Cell) {
+		if cell.IsMine {
+			return
+		}
+		total += b.GhostReveal(x, y)
+		safe++
+	})
+	if safe == 0 {
+		return 0
+	}
+	return float64(total) / float64(safe)
+}
+
+// SafeOpeningCells returns the unrevealed, non-mine cells with no adjacent mines.
+// Revealing any of them triggers a flood fill, so on a fresh board these are the cells that open the most.
+func (b *Board) SafeOpeningCells() [][2]int {
+	return b.FilterCells(func(x, y int, cell Cell) bool {
+		return !cell.IsMine && !cell.Revealed && cell.AdjMines == 0
+	})
+}
+
+// MaxCascade returns the largest number of cells a single reveal could open, trying every unrevealed non-mine cell with GhostReveal.
+// This is O(n²), which is fine for normal boards. On boards larger than 100x100 it stops early once a cascade covers more than half of the safe cells.
+func (b *Board) MaxCascade() int {
+	large := b.Width*b.Height > 100*100
+	safe := b.CountCellsWhere(func(x, y int, cell Cell) bool { return !cell.IsMine })
+
+	best := 0
+	for y := range b.Cells {
+		for x := range b.Cells[y] {
+			cell := b.Cells[y][x]
+			if cell.IsMine || cell.Revealed {
+				continue
+			}
+			best = max(best, b.GhostReveal(x, y))
+			if large && best*2 > safe {
+				return best
+			}
+		}
+	}
+	return best
+}
+
+// WorstCaseReveal returns the fewest cells that clicking (x, y) is guaranteed to open, if the mines were placed by an adversary after the click.
+// Every placement of the board's mines over the other unrevealed cells that agrees with the revealed numbers is tried, and the smallest GhostReveal among them wins.
+// This is exponential in the number of unrevealed cells, so it's only tractable for small boards. It stops early once the minimum hits 1, as the clicked cell is always opened.
+// It returns 0 if (x, y) is not an unrevealed cell, or if no placement leaving it safe fits the board.
+func (b *Board) WorstCaseReveal(x, y int) int {
+	if !b.isValidCell(x, y) || b.Cells[y][x].Revealed {
+		return 0
+	}
+
+	ghost := b.Clone()
+	var candidates, numbers [][2]int
+	mines := b.TotalMines
+	ghost.ForEachCellPtr(func(cx, cy int, cell *Cell) {
+		if cell.Revealed {
+			if cell.IsMine {
+				mines--
+			} else {
+				numbers = append(numbers, [2]int{cx, cy})
+			}
+			return
+		}
+		cell.IsMine = false
+		if cx != x || cy != y {
+			candidates = append(candidates, [2]int{cx, cy})
+		}
+	})
+	if mines < 0 || mines > len(candidates) {
+		return 0
+	}
+	// Try the neighbors of (x, y) first, a mine next to the click is the adversary's best answer and lets us stop early
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return isAdjacent(candidates[i], x, y) && !isAdjacent(candidates[j], x, y)
+	})
+
+	worst := -1
+	var place func(start, remaining int)
+	place = func(start, remaining int) {
+		if worst == 1 {
+			return
+		}
+		if remaining == 0 {
+			// Skip placements that contradict what the player has already seen
+			for _, c := range numbers {
+				if ghost.countAdjMines(c[0], c[1]) != b.Cells[c[1]][c[0]].AdjMines {
+					return
+				}
+			}
+			ghost.ForEachCellPtr(func(cx, cy int, cell *Cell) {
+				cell.AdjMines = ghost.countAdjMines(cx, cy)
+			})
+			if n := ghost.GhostReveal(x, y); worst == -1 || n < worst {
+				worst = n
+			}
+			return
+		}
+		for i := start; i <= len(candidates)-remaining; i++ {
+			c := candidates[i]
+			ghost.Cells[c[1]][c[0]].IsMine = true
+			place(i+1, remaining-1)
+			ghost.Cells[c[1]][c[0]].IsMine = false
+		}
+	}
+	place(0, mines)
+	return max(worst, 0)
+}
+
+// isAdjacent checks if c is one of the 8 neighbors of (x, y).
+func isAdjacent(c [2]int, x, y int) bool {
+	dx, dy := c[0]-x, c[1]-y
+	return (dx != 0 || dy != 0) && dx >= -1 && dx <= 1 && dy >= -1 && dy <= 1
+}
+
+// OptimalFirstMove returns the best cell to open, using the known mine layout.
+// Only unrevealed non-mine cells are considered. The cell opening the most cells (GhostReveal) wins,
+// ties go to the cell with the lowest visible mine probability, then to the best WorstCaseReveal, then to the first in row-major order.
+// It returns Point{-1, -1} if there is no safe cell left to open.
+func (b *Board) OptimalFirstMove() Point {
+	probs := b.MineProbability()
+	best := Point{X: -1, Y: -1}
+	bestOpen, bestWorst := 0, 0
+	b.ForEachCell(func(x, y int, cell Cell) {
+		if cell.IsMine || cell.Revealed {
+			return
+		}
+		open := b.GhostReveal(x, y)
+		if best.X >= 0 {
+			if open < bestOpen {
+				return
+			}
+			if open == bestOpen {
+				if p, bp := probs[y][x], probs[best.Y][best.X]; p > bp {
+					return
+				} else if p == bp {
+					if bestWorst == 0 {
+						bestWorst = b.WorstCaseReveal(best.X, best.Y)
+					}
+					if b.WorstCaseReveal(x, y) <= bestWorst {
+						return
+					}
+				}
+			}
+		}
+		best, bestOpen, bestWorst = Point{X: x, Y: y}, open, 0
+	})
+	return best
+}
@@ -0,0 +1,441 @@
+package main
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func TestCornerStrategy(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		reveal   [][2]int
+		want     [][2]int
+	}{
+		{"3x3 corners", "...\n...\n...", nil, [][2]int{{0, 0}, {2, 0}, {0, 2}, {2, 2}}},
+		{"1x1 single cell", ".", nil, [][2]int{{0, 0}}},
+		{"1x3 column", ".\n.\n.", nil, [][2]int{{0, 0}, {0, 2}}},
+		{"revealed corner skipped", "M..\n...\n..M", [][2]int{{2, 0}}, [][2]int{{0, 0}, {0, 2}, {2, 2}}},
+		{"edges once corners are open", "M...\n....\n....\n...M", [][2]int{{0, 0}, {3, 0}, {0, 3}, {3, 3}}, [][2]int{{1, 0}, {2, 0}, {0, 1}, {3, 1}, {0, 2}, {3, 2}, {1, 3}, {2, 3}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := boardFromTemplate(t, tt.template)
+			for _, c := range tt.reveal {
+				b.Cells[c[1]][c[0]].Revealed = true
+			}
+			if got := b.CornerStrategy(); !slices.Equal(got, tt.want) {
+				t.Errorf("CornerStrategy() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGhostReveal(t *testing.T) {
+	const template = `
+M....
+.....
+....M
+`
+	tests := []struct {
+		name string
+		x, y int
+	}{
+		{"flood fill", 2, 0},
+		{"numbered cell", 1, 1},
+		{"already revealed", 1, 0},
+		{"mine", 0, 0},
+		{"off the board", 9, 9},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := boardFromTemplate(t, template)
+			b.RevealCell(1, 0)
+			before := b.Clone()
+			got := b.GhostReveal(tt.x, tt.y)
+			if !slices.EqualFunc(b.Cells, before.Cells, slices.Equal) || b.State != before.State {
+				t.Fatal("GhostReveal changed the board")
+			}
+
+			revealed := b.CountRevealed()
+			b.RevealCell(tt.x, tt.y)
+			if want := b.CountRevealed() - revealed; got != want {
+				t.Errorf("GhostReveal(%d, %d) = %d, RevealCell opened %d", tt.x, tt.y, got, want)
+			}
+		})
+	}
+}
+
+func TestOpeningScore(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		want     float64
+	}{
+		{"mine-free", "....\n....\n....", 12},
+		{"mine-free 1x1", ".", 1},
+		{"every safe cell touches a mine", "M.M\n.M.\nM.M", 1},
+		{"packed", "MMM\nM.M\nMMM", 1},
+		{"all mines", "MM\nMM", 0},
+		// The two zeros on the left open 4 cells each, the numbers next to the mines only themselves
+		{"zeros and numbers", "..M.\n..M.", (4 + 4 + 1 + 1 + 1 + 1) / 6.0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := boardFromTemplate(t, tt.template).OpeningScore(); got != tt.want {
+				t.Errorf("OpeningScore() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSafeOpeningCells(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		reveal   [][2]int
+		want     int
+	}{
+		{"fresh board", "M....\n.....\n....M\n.....", nil, 10},
+		{"after a reveal", "M....\n.....\n....M\n.....", [][2]int{{2, 0}}, 0},
+		{"no zeros", "M.M\n.M.", nil, 0},
+		{"mine-free", "...\n...", nil, 6},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := boardFromTemplate(t, tt.template)
+			for _, c := range tt.reveal {
+				b.RevealCell(c[0], c[1])
+			}
+			got := b.SafeOpeningCells()
+			if len(got) != tt.want {
+				t.Errorf("SafeOpeningCells() returned %d cells, want %d", len(got), tt.want)
+			}
+			for _, c := range got {
+				if cell := b.Cells[c[1]][c[0]]; cell.IsMine || cell.AdjMines != 0 || cell.Revealed {
+					t.Errorf("SafeOpeningCells() returned (%d,%d): %+v", c[0], c[1], cell)
+				}
+			}
+		})
+	}
+}
+
+func TestMaxCascade(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		reveal   [][2]int
+		want     int
+	}{
+		{"one opening covers every safe cell", "M....\n.....\n....M", nil, 13},
+		{"zeros left of the wall", "..M.\n..M.", nil, 4},
+		{"opening already revealed", "..M.\n..M.", [][2]int{{0, 0}}, 1},
+		{"no safe cell", "MM\nMM", nil, 0},
+		{"larger of two openings", "...M....\n...M....", nil, 8},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := boardFromTemplate(t, tt.template)
+			for _, c := range tt.reveal {
+				b.RevealCell(c[0], c[1])
+			}
+			if got := b.MaxCascade(); got != tt.want {
+				t.Errorf("MaxCascade() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMaxCascadeLargeBoard(t *testing.T) {
+	// Past 100x100 the search stops at the first cascade covering more than half the safe cells
+	b := newEmptyBoard(101, 100)
+	if got, want := b.MaxCascade(), 101*100; got != want {
+		t.Errorf("MaxCascade() = %d, want %d", got, want)
+	}
+}
+
+func TestWorstCaseReveal(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		reveal   [][2]int
+		x, y     int
+		want     int
+	}{
+		{"mine-free 1x1", ".", nil, 0, 0, 1},
+		{"mine-free 3x2 opens everything", "...\n...", nil, 0, 0, 6},
+		{"revealed column splits the board", "...\n...\n...", [][2]int{{1, 0}, {1, 1}, {1, 2}}, 0, 0, 3},
+		// With a mine to place, the adversary puts it next to the click so only the clicked cell opens
+		{"one mine in a row", "..M", nil, 0, 0, 1},
+		{"one mine on a 3x3", "M..\n...\n...", nil, 1, 1, 1},
+		{"corner on a 4x4", "...M\n....\n....\n....", nil, 0, 0, 1},
+		// The revealed 1 needs the mine at x=0 or x=2, so the adversary can't put it at x=3 next to the click
+		{"revealed number rules out the worst placement", "M....", [][2]int{{1, 0}}, 4, 0, 2},
+		{"revealed numbers make the click a mine", "M...", [][2]int{{1, 0}, {2, 0}}, 0, 0, 0},
+		{"revealed cell", "...\n..M", [][2]int{{0, 0}}, 0, 0, 0},
+		{"off the board", "...", nil, 3, 0, 0},
+		{"mines don't fit", "MM", nil, 0, 0, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := boardFromTemplate(t, tt.template)
+			for _, c := range tt.reveal {
+				b.Cells[c[1]][c[0]].Revealed = true
+			}
+			if got := b.WorstCaseReveal(tt.x, tt.y); got != tt.want {
+				t.Errorf("WorstCaseReveal(%d, %d) = %d, want %d", tt.x, tt.y, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOptimalFirstMove(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		want     Point
+	}{
+		{"zeros left of the wall", "..M.\n..M.", Point{X: 0, Y: 0}},
+		{"largest opening", "M....", Point{X: 2, Y: 0}},
+		{"larger of two openings", "...M....\n...M....", Point{X: 5, Y: 0}},
+		{"single safe cell", "MM\nM.", Point{X: 1, Y: 1}},
+		{"no safe cell", "MM", Point{X: -1, Y: -1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := boardFromTemplate(t, tt.template).OptimalFirstMove(); got != tt.want {
+				t.Errorf("OptimalFirstMove() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOptimalFirstMoveIsSafe(t *testing.T) {
+	for seed := int64(1); seed <= 20; seed++ {
+		b := NewBoard(6, 6, 8, WithSeed(seed))
+		p := b.OptimalFirstMove()
+		if !b.isValidCell(p.X, p.Y) || b.Cells[p.Y][p.X].IsMine {
+			t.Errorf("seed %d: OptimalFirstMove() = %v, which isn't a safe cell", seed, p)
+		}
+	}
+}
+
+func TestLayeredReveal(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		x, y     int
+		layers   int
+		want     []int
+	}{
+		{"row", "....M", 0, 0, 10, []int{1, 1, 1, 1}},
+		{"row cut short", "....M", 0, 0, 2, []int{1, 1}},
+		{"rings from a corner", "....\n....\n....\n...M", 0, 0, 10, []int{1, 3, 5, 6}},
+		{"from the middle", ".....\n.....\n.....\n.....\n.....", 2, 2, 10, []int{1, 8, 16}},
+		{"number", "M..", 1, 0, 10, []int{1}},
+		{"mine", "M..", 0, 0, 10, []int{1}},
+		{"no layers", "M..", 2, 0, 0, nil},
+		{"off the board", "M..", 3, 0, 10, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := boardFromTemplate(t, tt.template)
+			got := b.LayeredReveal(tt.x, tt.y, tt.layers)
+			sizes := make([]int, len(got))
+			for i, layer := range got {
+				sizes[i] = len(layer)
+			}
+			if !slices.Equal(sizes, tt.want) {
+				t.Errorf("layer sizes = %v, want %v", sizes, tt.want)
+			}
+			if b.CountRevealed() != 0 {
+				t.Error("LayeredReveal revealed cells on the board")
+			}
+
+			seen := make(map[Point]bool)
+			for i, layer := range got {
+				for _, p := range layer {
+					if seen[p] {
+						t.Errorf("cell %v repeats in layer %d", p, i)
+					}
+					seen[p] = true
+				}
+			}
+		})
+	}
+}
+
+func TestLayeredRevealMatchesRevealCell(t *testing.T) {
+	b := boardFromTemplate(t, "......\n..M...\n......\n.....M\n......")
+	b.RevealCell(5, 0)
+	before := b.Clone()
+	layered := b.LayeredReveal(0, 4, b.Width*b.Height)
+	b.RevealCell(0, 4)
+	var want []Point
+	b.ForEachCell(func(x, y int, cell Cell) {
+		if cell.Revealed && !before.Cells[y][x].Revealed {
+			want = append(want, Point{X: x, Y: y})
+		}
+	})
+	got := slices.Concat(layered...)
+	if !slices.Equal(sortedPoints(got), want) {
+		t.Errorf("layers cover %v\nRevealCell revealed %v", sortedPoints(got), want)
+	}
+}
+
+func TestRandomOpening(t *testing.T) {
+	corners := [][2]int{{0, 0}, {2, 0}, {0, 2}, {2, 2}}
+	edges := [][2]int{{1, 0}, {0, 1}, {2, 1}, {1, 2}}
+	tests := []struct {
+		name     string
+		revealed [][2]int
+		want     [][2]int
+	}{
+		{"fresh board picks corners", nil, corners},
+		{"remaining corners", [][2]int{{0, 0}, {2, 2}}, [][2]int{{2, 0}, {0, 2}}},
+		{"edges once the corners are open", corners, edges},
+		{"middle once the ring is open", slices.Concat(corners, edges), [][2]int{{1, 1}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := newEmptyBoard(3, 3)
+			for _, c := range tt.revealed {
+				b.Cells[c[1]][c[0]].Revealed = true
+			}
+			r := rand.New(rand.NewSource(1))
+			seen := make(map[[2]int]bool)
+			for i := 0; i < 100; i++ {
+				p := b.RandomOpening(r)
+				if !slices.Contains(tt.want, [2]int{p.X, p.Y}) {
+					t.Fatalf("RandomOpening() = %v, want one of %v", p, tt.want)
+				}
+				seen[[2]int{p.X, p.Y}] = true
+			}
+			if len(seen) != len(tt.want) {
+				t.Errorf("100 picks only hit %v, want every one of %v", seen, tt.want)
+			}
+		})
+	}
+}
+
+func TestRandomOpeningSeed(t *testing.T) {
+	b := newEmptyBoard(9, 9)
+	for seed := int64(0); seed < 10; seed++ {
+		first := b.RandomOpening(rand.New(rand.NewSource(seed)))
+		if again := b.RandomOpening(rand.New(rand.NewSource(seed))); again != first {
+			t.Errorf("seed %d picked %v, then %v", seed, first, again)
+		}
+	}
+}
+
+func TestRandomOpeningAllRevealed(t *testing.T) {
+	b := newEmptyBoard(2, 2)
+	b.ForEachCellPtr(func(x, y int, cell *Cell) { cell.Revealed = true })
+	if got := b.RandomOpening(rand.New(rand.NewSource(1))); got != (Point{X: -1, Y: -1}) {
+		t.Errorf("RandomOpening() on a revealed board = %v, want (-1, -1)", got)
+	}
+}
+
+func TestSpiralReveal(t *testing.T) {
+	tests := []struct {
+		name           string
+		width, height  int
+		startX, startY int
+		prefix         []Point
+	}{
+		// Clockwise from north: N, NE, E, SE, S, SW, W, NW
+		{"middle", 5, 5, 2, 2, []Point{{2, 2}, {2, 1}, {3, 1}, {3, 2}, {3, 3}, {2, 3}, {1, 3}, {1, 2}, {1, 1}}},
+		{"corner", 3, 3, 0, 0, []Point{{0, 0}, {1, 0}, {1, 1}, {0, 1}, {2, 0}}},
+		{"edge", 4, 3, 3, 1, []Point{{3, 1}, {3, 0}, {3, 2}, {2, 2}, {2, 1}, {2, 0}}},
+		{"single cell", 1, 1, 0, 0, []Point{{0, 0}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := newEmptyBoard(tt.width, tt.height)
+			got := b.SpiralReveal(tt.startX, tt.startY)
+			if len(got) != tt.width*tt.height {
+				t.Fatalf("SpiralReveal() returned %d cells, want %d", len(got), tt.width*tt.height)
+			}
+			if !slices.Equal(got[:len(tt.prefix)], tt.prefix) {
+				t.Errorf("SpiralReveal() starts %v, want %v", got[:len(tt.prefix)], tt.prefix)
+			}
+			seen := make(map[Point]bool)
+			last := 0
+			for _, p := range got {
+				if seen[p] {
+					t.Errorf("cell %v appears twice", p)
+				}
+				seen[p] = true
+				d := max(abs(p.X-tt.startX), abs(p.Y-tt.startY))
+				if d < last {
+					t.Errorf("distance drops from %d to %d at %v", last, d, p)
+				}
+				last = d
+			}
+			if b.CountRevealed() != 0 {
+				t.Error("SpiralReveal revealed cells on the board")
+			}
+		})
+	}
+}
+
+func TestSpiralRevealOffBoard(t *testing.T) {
+	if got := newEmptyBoard(3, 3).SpiralReveal(3, 0); got != nil {
+		t.Errorf("SpiralReveal() off the board = %v, want nil", got)
+	}
+}
+
+func TestRandomWalkReveal(t *testing.T) {
+	tests := []struct {
+		name           string
+		template       string
+		startX, startY int
+		steps          int
+		maxLen         int
+	}{
+		{"open board", ".....\n.....\n.....\n.....\n.....", 2, 2, 10, 11},
+		{"no steps", ".....\n.....", 0, 0, 0, 1},
+		{"boxed in by mines", "M.M\nMMM", 1, 0, 5, 1},
+		{"runs out of cells", "...", 0, 0, 10, 3},
+		{"start on a mine", "M..", 0, 0, 5, 0},
+		{"start off the board", "...", 3, 0, 5, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := boardFromTemplate(t, tt.template)
+			walk := b.RandomWalkReveal(tt.startX, tt.startY, tt.steps, rand.New(rand.NewSource(7)))
+			if len(walk) > tt.maxLen {
+				t.Fatalf("walk has %d cells, want at most %d: %v", len(walk), tt.maxLen, walk)
+			}
+			if tt.maxLen > 0 && (len(walk) == 0 || walk[0] != (Point{X: tt.startX, Y: tt.startY})) {
+				t.Errorf("walk %v doesn't start at (%d,%d)", walk, tt.startX, tt.startY)
+			}
+			seen := make(map[Point]bool)
+			for i, p := range walk {
+				if !b.isValidCell(p.X, p.Y) || b.Cells[p.Y][p.X].IsMine || !b.Cells[p.Y][p.X].Revealed {
+					t.Errorf("step %d at %v isn't a revealed safe cell", i, p)
+				}
+				if seen[p] {
+					t.Errorf("cell %v visited twice", p)
+				}
+				seen[p] = true
+				if i > 0 && max(abs(p.X-walk[i-1].X), abs(p.Y-walk[i-1].Y)) != 1 {
+					t.Errorf("step %d jumps from %v to %v", i, walk[i-1], p)
+				}
+			}
+			if b.CountRevealed() != len(walk) {
+				t.Errorf("%d cells revealed, the walk has %d", b.CountRevealed(), len(walk))
+			}
+		})
+	}
+}
+
+func TestRandomWalkRevealSeed(t *testing.T) {
+	const template = "........\n........\n..M.....\n........\n.....M..\n........"
+	first := boardFromTemplate(t, template).RandomWalkReveal(0, 0, 20, rand.New(rand.NewSource(42)))
+	again := boardFromTemplate(t, template).RandomWalkReveal(0, 0, 20, rand.New(rand.NewSource(42)))
+	if !slices.Equal(first, again) {
+		t.Errorf("the same seed walked %v, then %v", first, again)
+	}
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// GenerateFromTemplate builds a board with a fixed mine layout, for tests and hand-made puzzles.
+// The template has one line per row, with '.' for a safe cell and 'M' for a mine, e.g.
+//
+//	..M
+//	...
+//	M..
+//
+// Leading and trailing blank lines are ignored. It returns an error wrapping ErrInvalidTemplate if the rows have different lengths or contain any other character.
+func GenerateFromTemplate(template string) (*Board, error) {
+	rows := strings.Split(strings.Trim(template, "\r\n"), "\n")
+	width := len(strings.TrimRight(rows[0], "\r"))
+	if width == 0 {
+		return nil, fmt.Errorf("%w: empty template", ErrInvalidTemplate)
+	}
+
+	board := newEmptyBoard(width, len(rows))
+	for y, row := range rows {
+		row = strings.TrimRight(row, "\r")
+		if len(row) != width {
+			return nil, fmt.Errorf("%w: row %d has %d cells, expected %d", ErrInvalidTemplate, y+1, len(row), width)
+		}
+		for x, c := range row {
+			switch c {
+			case '.':
+			case 'M':
+				board.Cells[y][x].IsMine = true
+				board.TotalMines++
+			default:
+				return nil, fmt.Errorf("%w: unexpected %q at row %d, column %d", ErrInvalidTemplate, c, y+1, x+1)
+			}
+		}
+	}
+	board.calculateAdjMines()
+	return board, nil
+}
+
+// MineMap returns a fresh 2D slice, indexed [row][column], where true means the cell is a mine.
+// Modifying it doesn't affect the board.
+func (b *Board) MineMap() [][]bool {
+	return b.cellMap(func(cell Cell) bool { return cell.IsMine })
+}
+
+// RevealedMap is MineMap for revealed cells.
+func (b *Board) RevealedMap() [][]bool {
+	return b.cellMap(func(cell Cell) bool { return cell.Revealed })
+}
+
+// FlagMap is MineMap for flagged cells.
+func (b *Board) FlagMap() [][]bool {
+	return b.cellMap(func(cell Cell) bool { return cell.Flagged })
+}
+
+// QuestionMap is MineMap for cells marked with a question mark.
+func (b *Board) QuestionMap() [][]bool {
+	return b.cellMap(func(cell Cell) bool { return cell.Questioned })
+}
+
+// cellMap returns a fresh 2D slice, indexed [row][column], holding f for every cell.
+func (b *Board) cellMap(f func(cell Cell) bool) [][]bool {
+	m := make([][]bool, b.Height)
+	for y, row := range b.Cells {
+		m[y] = make([]bool, b.Width)
+		for x, cell := range row {
+			m[y][x] = f(cell)
+		}
+	}
+	return m
+}
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestGenerateFromTemplate(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		want     [][]bool
+	}{
+		{"single mine", "M", [][]bool{{true}}},
+		{"single safe cell", ".", [][]bool{{false}}},
+		{"rectangle", "..M\nM..", [][]bool{{false, false, true}, {true, false, false}}},
+		{"surrounding blank lines", "\n.M\nM.\n", [][]bool{{false, true}, {true, false}}},
+		{"windows line endings", ".M\r\nM.\r\n", [][]bool{{false, true}, {true, false}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := GenerateFromTemplate(tt.template)
+			if err != nil {
+				t.Fatalf("GenerateFromTemplate: %v", err)
+			}
+			if got := b.MineMap(); !slices.EqualFunc(got, tt.want, slices.Equal) {
+				t.Errorf("MineMap() = %v, want %v", got, tt.want)
+			}
+			checkAdjacency(t, b)
+		})
+	}
+}
+
+func TestGenerateFromTemplateInvalid(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+	}{
+		{"empty", ""},
+		{"ragged rows", "...\n..\n..."},
+		{"unknown character", "..x\n..."},
+		{"lowercase mine", "m.."},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := GenerateFromTemplate(tt.template); !errors.Is(err, ErrInvalidTemplate) {
+				t.Errorf("GenerateFromTemplate(%q) error = %v, want ErrInvalidTemplate", tt.template, err)
+			}
+		})
+	}
+}
+
+func TestCellMaps(t *testing.T) {
+	b := boardFromTemplate(t, "M..\n...\n..M")
+	b.RevealCell(1, 0)
+	b.FlagCell(0, 0)
+	b.FlagCell(2, 1)
+	b.QuestionCell(0, 2)
+	b.QuestionCell(2, 2)
+
+	tests := []struct {
+		name  string
+		get   func() [][]bool
+		field func(Cell) bool
+		want  [][]bool
+	}{
+		{"MineMap", b.MineMap, func(c Cell) bool { return c.IsMine }, [][]bool{{true, false, false}, {false, false, false}, {false, false, true}}},
+		{"RevealedMap", b.RevealedMap, func(c Cell) bool { return c.Revealed }, [][]bool{{false, true, false}, {false, false, false}, {false, false, false}}},
+		{"FlagMap", b.FlagMap, func(c Cell) bool { return c.Flagged }, [][]bool{{true, false, false}, {false, false, true}, {false, false, false}}},
+		{"QuestionMap", b.QuestionMap, func(c Cell) bool { return c.Questioned }, [][]bool{{false, false, false}, {false, false, false}, {true, false, true}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := tt.get()
+			if !slices.EqualFunc(m, tt.want, slices.Equal[[]bool]) {
+				t.Errorf("%s() = %v, want %v", tt.name, m, tt.want)
+			}
+			b.ForEachCell(func(x, y int, cell Cell) {
+				if m[y][x] != tt.field(cell) {
+					t.Errorf("%s()[%d][%d] = %v, the cell says %v", tt.name, y, x, m[y][x], tt.field(cell))
+				}
+			})
+
+			// The map is a copy, so changing it leaves the board alone and the next call fresh
+			before := b.Clone()
+			for _, row := range m {
+				for x := range row {
+					row[x] = !row[x]
+				}
+			}
+			if !slices.EqualFunc(before.Cells, b.Cells, slices.Equal[[]Cell]) {
+				t.Errorf("changing the result of %s() changed the board", tt.name)
+			}
+			if again := tt.get(); !slices.EqualFunc(again, tt.want, slices.Equal[[]bool]) {
+				t.Errorf("second %s() = %v, want %v", tt.name, again, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,89 @@
+package main
+
+// SubGrid returns a copy of the cells in the w x h rectangle whose top-left corner is (x, y), indexed [row][column] like Board.Cells.
+// Modifying the result doesn't affect the board. It returns nil if the rectangle doesn't fit on the board.
+func (b *Board) SubGrid(x, y, w, h int) [][]Cell {
+	if w <= 0 || h <= 0 || !b.isValidCell(x, y) || !b.isValidCell(x+w-1, y+h-1) {
+		return nil
+	}
+	grid := make([][]Cell, h)
+	for j := range grid {
+		grid[j] = append([]Cell(nil), b.Cells[y+j][x:x+w]...)
+	}
+	return grid
+}
+
+// MirrorDiagonal returns a transposed copy of the board: the cell at (x, y) moves to (y, x), so Width and Height are swapped.
+// The cell states, notes, cell history and excluded cells move with the cells, and the adjacency counts are recomputed for the new layout.
+func (b *Board) MirrorDiagonal() *Board {
+	mirrored := b.Clone()
+	mirrored.Width, mirrored.Height = b.Height, b.Width
+	mirrored.Cells = make([][]Cell, mirrored.Height)
+	for y := range mirrored.Cells {
+		mirrored.Cells[y] = make([]Cell, mirrored.Width)
+		for x := range mirrored.Cells[y] {
+			mirrored.Cells[y][x] = b.Cells[x][y]
+		}
+	}
+	mirrored.Notes = transposeKeys(mirrored.Notes)
+	mirrored.history = transposeKeys(mirrored.history)
+	mirrored.excluded = transposeKeys(mirrored.excluded)
+	mirrored.mineCandidates = nil
+	for _, c := range b.mineCandidates {
+		mirrored.mineCandidates = append(mirrored.mineCandidates, [2]int{c[1], c[0]})
+	}
+	mirrored.calculateAdjMines()
+	return mirrored
+}
+
+// transposeKeys returns a copy of a map keyed by cell with every (x, y) key moved to (y, x). A nil map stays nil.
+func transposeKeys[V any](m map[[2]int]V) map[[2]int]V {
+	if m == nil {
+		return nil
+	}
+	transposed := make(map[[2]int]V, len(m))
+	for c, v := range m {
+		transposed[[2]int{c[1], c[0]}] = v
+	}
+	return transposed
+}
+
+// SymmetryType describes which symmetries a board's mine layout has
+type SymmetryType int
+
+const (
+	SymmetryNone SymmetryType = iota
+	// SymmetryHorizontal is a left-right mirror image
+	SymmetryHorizontal
+	// SymmetryVertical is a top-bottom mirror image
+	SymmetryVertical
+	// SymmetryRotational180 looks the same when rotated by 180 degrees
+	SymmetryRotational180
+	// SymmetryFull has all three of the above
+	SymmetryFull
+)
+
+// SymmetryType checks the mine layout for left-right, top-bottom and 180 degree rotational symmetry.
+// Any two of these imply the third, so a board with more than one symmetry is reported as SymmetryFull.
+func (b *Board) SymmetryType() SymmetryType {
+	horizontal, vertical, rotational := true, true, true
+	b.ForEachCell(func(x, y int, cell Cell) {
+		mx, my := b.Width-1-x, b.Height-1-y
+		horizontal = horizontal && cell.IsMine == b.Cells[y][mx].IsMine
+		vertical = vertical && cell.IsMine == b.Cells[my][x].IsMine
+		rotational = rotational && cell.IsMine == b.Cells[my][mx].IsMine
+	})
+
+	switch {
+	case horizontal && vertical:
+		return SymmetryFull
+	case horizontal:
+		return SymmetryHorizontal
+	case vertical:
+		return SymmetryVertical
+	case rotational:
+		return SymmetryRotational180
+	default:
+		return SymmetryNone
+	}
+}
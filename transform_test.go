@@ -0,0 +1,179 @@
+package main
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestMirrorDiagonal(t *testing.T) {
+	b := boardFromTemplate(t, `
+..M
+M..
+`)
+	b.RevealCell(2, 1)
+	b.FlagCell(2, 0)
+	m := b.MirrorDiagonal()
+	if m.Width != 2 || m.Height != 3 {
+		t.Fatalf("mirrored board is %dx%d, want 2x3", m.Width, m.Height)
+	}
+	b.ForEachCell(func(x, y int, cell Cell) {
+		got := m.Cells[x][y]
+		if got.IsMine != cell.IsMine || got.Revealed != cell.Revealed || got.Flagged != cell.Flagged {
+			t.Errorf("cell (%d,%d) = %+v after mirroring, want the state of (%d,%d) %+v", y, x, got, x, y, cell)
+		}
+	})
+	if got := m.Cells[2][1].AdjMines; got != 1 {
+		t.Errorf("adjacent mines of (1,2) = %d, want 1", got)
+	}
+	if twice := m.MirrorDiagonal(); !slices.EqualFunc(twice.Cells, b.Cells, slices.Equal) {
+		t.Error("mirroring twice didn't give back the original board")
+	}
+}
+
+func TestMirrorDiagonalMines(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		want     string
+	}{
+		{"3x2 to 2x3", "..M\nM..", ".M\n..\nM."},
+		{"row to column", "M.M", "M\n.\nM"},
+		{"square", "M...\n.M..\n...M\n..M.", "M...\n.M..\n...M\n..M."},
+		{"single cell", "M", "M"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := boardFromTemplate(t, tt.template).MirrorDiagonal()
+			want := boardFromTemplate(t, tt.want)
+			if got.Width != want.Width || got.Height != want.Height {
+				t.Fatalf("mirrored board is %dx%d, want %dx%d", got.Width, got.Height, want.Width, want.Height)
+			}
+			if !slices.EqualFunc(got.Cells, want.Cells, slices.Equal) {
+				t.Errorf("mirrored cells = %v, want %v", got.Cells, want.Cells)
+			}
+			checkAdjacency(t, got)
+		})
+	}
+}
+
+func TestMirrorDiagonalMovesCellState(t *testing.T) {
+	b := NewBoard(3, 2, 0, EnableCellHistory(), WithExcludedCells([][2]int{{2, 1}}))
+	b.AnnotateCell(2, 0, "corner")
+	b.FlagCell(2, 0)
+	m := b.MirrorDiagonal()
+
+	if got := m.CellNote(0, 2); got != "corner" {
+		t.Errorf("note at (0,2) = %q, want %q", got, "corner")
+	}
+	if _, ok := m.Notes[[2]int{2, 0}]; ok {
+		t.Error("note left at (2,0), which is off the mirrored board")
+	}
+	if got := m.CellHistory(0, 2); !slices.Equal(got, b.CellHistory(2, 0)) {
+		t.Errorf("history of (0,2) = %v, want %v", got, b.CellHistory(2, 0))
+	}
+	if !m.excluded[[2]int{1, 2}] || m.excluded[[2]int{2, 1}] {
+		t.Errorf("excluded cells = %v, want only (1,2)", m.excluded)
+	}
+
+	data, err := m.Serialize()
+	if err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := DeserializeBoard(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := loaded.CellNote(0, 2); got != "corner" {
+		t.Errorf("note at (0,2) after a save round trip = %q, want %q", got, "corner")
+	}
+}
+
+func TestSubGrid(t *testing.T) {
+	b := boardFromTemplate(t, `
+M...
+.M..
+..M.
+`)
+	tests := []struct {
+		name       string
+		x, y, w, h int
+		wantMines  [][2]int
+		wantNil    bool
+	}{
+		{"whole board", 0, 0, 4, 3, [][2]int{{0, 0}, {1, 1}, {2, 2}}, false},
+		{"inner 2x2", 1, 1, 2, 2, [][2]int{{0, 0}, {1, 1}}, false},
+		{"single cell", 3, 2, 1, 1, nil, false},
+		{"past the right edge", 2, 0, 3, 1, nil, true},
+		{"past the bottom", 0, 2, 1, 2, nil, true},
+		{"empty", 0, 0, 0, 1, nil, true},
+		{"negative origin", -1, 0, 2, 2, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			grid := b.SubGrid(tt.x, tt.y, tt.w, tt.h)
+			if tt.wantNil {
+				if grid != nil {
+					t.Errorf("SubGrid = %v, want nil", grid)
+				}
+				return
+			}
+			if len(grid) != tt.h {
+				t.Fatalf("SubGrid has %d rows, want %d", len(grid), tt.h)
+			}
+			var mines [][2]int
+			for j, row := range grid {
+				if len(row) != tt.w {
+					t.Fatalf("row %d has %d cells, want %d", j, len(row), tt.w)
+				}
+				for i, cell := range row {
+					if cell != b.Cells[tt.y+j][tt.x+i] {
+						t.Errorf("cell (%d,%d) = %+v, want %+v", i, j, cell, b.Cells[tt.y+j][tt.x+i])
+					}
+					if cell.IsMine {
+						mines = append(mines, [2]int{i, j})
+					}
+				}
+			}
+			if !slices.Equal(mines, tt.wantMines) {
+				t.Errorf("mines at %v, want %v", mines, tt.wantMines)
+			}
+		})
+	}
+}
+
+func TestSubGridIsCopy(t *testing.T) {
+	b := boardFromTemplate(t, "M.\n..")
+	grid := b.SubGrid(0, 0, 2, 2)
+	grid[0][0].IsMine = false
+	grid[1][1].Revealed = true
+	if !b.Cells[0][0].IsMine || b.Cells[1][1].Revealed {
+		t.Error("modifying the SubGrid result changed the board")
+	}
+}
+
+func TestSymmetryType(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		want     SymmetryType
+	}{
+		{"asymmetric", "M..\n...\n...", SymmetryNone},
+		{"asymmetric two mines", "MM.\n...\n..M", SymmetryNone},
+		{"left-right", "M.M\n...\n...", SymmetryHorizontal},
+		{"top-bottom", "M..\n...\nM..", SymmetryVertical},
+		{"rotation by 180", "M..\n...\n..M", SymmetryRotational180},
+		{"rotation on an even board", "MM..\n....\n..MM", SymmetryRotational180},
+		{"all four corners", "M.M\n...\nM.M", SymmetryFull},
+		{"center mine", "...\n.M.\n...", SymmetryFull},
+		{"no mines", "...\n...", SymmetryFull},
+		{"single row is its own top-bottom mirror", "MM..", SymmetryVertical},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := boardFromTemplate(t, tt.template)
+			if got := b.SymmetryType(); got != tt.want {
+				t.Errorf("SymmetryType() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+)
+
+// TutorialStep struct is one scripted step of the tutorial
+// The player has to enter ExpectedCmd to move on, Hint is shown whenever they enter something else
+type TutorialStep struct {
+	Instruction string
+	ExpectedCmd Move
+	Hint        string
+}
+
+// tutorialSteps is the script for the tutorial board built by newTutorialBoard.
+// The mines are at (4, 3) and (3, 4), so one reveal opens everything but the bottom-right corner.
+var tutorialSteps = []TutorialStep{
+	{
+		Instruction: "First, let's reveal the cell in the second column of the second row. Type 'reveal 2 2'.",
+		ExpectedCmd: Move{Cmd: CmdReveal, X: 1, Y: 1},
+		Hint:        "Not quite. Type 'reveal 2 2' to reveal the cell at column 2, row 2.",
+	},
+	{
+		Instruction: "That cell had no mines around it, so its neighbors opened up too. The 1 at (4, 2) touches only one hidden cell, so (4, 3) must be a mine. Type 'flag 4 3'.",
+		ExpectedCmd: Move{Cmd: CmdFlag, X: 3, Y: 2},
+		Hint:        "The only hidden cell next to the 1 at (4, 2) is (4, 3). Type 'flag 4 3' to mark it.",
+	},
+	{
+		Instruction: "The same goes for the 1 at (2, 4), its only hidden neighbor is (3, 4). Type 'flag 3 4'.",
+		ExpectedCmd: Move{Cmd: CmdFlag, X: 2, Y: 3},
+		Hint:        "Look at the 1 at (2, 4), which hidden cell could its mine be in? Type 'flag 3 4'.",
+	},
+	{
+		Instruction: "The 2 at (3, 3) now has both of its mines flagged, so the last hidden cell is safe. Type 'reveal 4 4'.",
+		ExpectedCmd: Move{Cmd: CmdReveal, X: 3, Y: 3},
+		Hint:        "Both mines next to the 2 at (3, 3) are flagged, so (4, 4) is safe. Type 'reveal 4 4'.",
+	},
+}
+
+// Tutorial struct walks a new player through a pre-generated board
+type Tutorial struct {
+	Board   *Board
+	Steps   []TutorialStep
+	Current int
+}
+
+// NewTutorial creates a tutorial at its first step.
+func NewTutorial() *Tutorial {
+	return &Tutorial{Board: newTutorialBoard(), Steps: tutorialSteps}
+}
+
+// tutorialTemplate is the fixed board the tutorial script is written for.
+const tutorialTemplate = `....
+....
+...M
+..M.`
+
+// newTutorialBoard builds the tutorial board from its template.
+func newTutorialBoard() *Board {
+	board, err := GenerateFromTemplate(tutorialTemplate)
+	if err != nil {
+		panic(err)
+	}
+	return board
+}
+
+// Done reports whether every step has been completed.
+func (t *Tutorial) Done() bool {
+	return t.Current >= len(t.Steps)
+}
+
+// Submit checks a move against the current step. If it matches, the move is applied and the tutorial advances.
+func (t *Tutorial) Submit(m Move) bool {
+	if t.Done() || m != t.Steps[t.Current].ExpectedCmd {
+		return false
+	}
+	switch m.Cmd {
+	case CmdReveal:
+		t.Board.RevealCell(m.X, m.Y)
+	case CmdFlag:
+		t.Board.FlagCell(m.X, m.Y)
+	}
+	t.Current++
+	return true
+}
+
+// Run plays the tutorial, reading moves from in and writing the board and instructions to out.
+// It returns early if the player quits or the input runs out.
+func (t *Tutorial) Run(in io.Reader, out io.Writer) {
+	scanner := bufio.NewScanner(in)
+	fmt.Fprintln(out, "Welcome to Minesweeper! Coordinates are a 1-based index, (1, 1) is the top-left corner.")
+	for !t.Done() {
+		t.Board.PrintBoardToWriter(out, false)
+		fmt.Fprintln(out, t.Steps[t.Current].Instruction)
+
+		if !scanner.Scan() {
+			return
+		}
+		move, err := parseMove(scanner.Text())
+		if err == nil && move.Cmd == CmdQuit {
+			fmt.Fprintln(out, "Quit tutorial.")
+			return
+		}
+		if err != nil || !t.Submit(move) {
+			fmt.Fprintln(out, t.Steps[t.Current].Hint)
+		}
+	}
+	t.Board.PrintBoardToWriter(out, true)
+	fmt.Fprintln(out, "Congratulations, you finished the tutorial!")
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestTutorialSubmit(t *testing.T) {
+	tests := []struct {
+		name    string
+		moves   []Move
+		want    []bool
+		current int
+	}{
+		{"wrong first move", []Move{{Cmd: CmdReveal, X: 0, Y: 0}}, []bool{false}, 0},
+		{"right command, wrong cell", []Move{{Cmd: CmdFlag, X: 1, Y: 1}}, []bool{false}, 0},
+		{"correct then wrong", []Move{{Cmd: CmdReveal, X: 1, Y: 1}, {Cmd: CmdFlag, X: 2, Y: 3}}, []bool{true, false}, 1},
+		{"whole script", []Move{
+			{Cmd: CmdReveal, X: 1, Y: 1},
+			{Cmd: CmdFlag, X: 3, Y: 2},
+			{Cmd: CmdFlag, X: 2, Y: 3},
+			{Cmd: CmdReveal, X: 3, Y: 3},
+			{Cmd: CmdReveal, X: 0, Y: 0},
+		}, []bool{true, true, true, true, false}, 4},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tut := NewTutorial()
+			for i, m := range tt.moves {
+				if got := tut.Submit(m); got != tt.want[i] {
+					t.Errorf("Submit(%v) = %v, want %v", m, got, tt.want[i])
+				}
+			}
+			if tut.Current != tt.current {
+				t.Errorf("Current = %d, want %d", tut.Current, tt.current)
+			}
+		})
+	}
+}
+
+func TestTutorialScriptWinsBoard(t *testing.T) {
+	tut := NewTutorial()
+	for _, step := range tut.Steps {
+		if !tut.Submit(step.ExpectedCmd) {
+			t.Fatalf("step %q was rejected", step.Instruction)
+		}
+	}
+	if !tut.Done() || tut.Board.State != StateWon {
+		t.Errorf("after the script Done() = %v, State = %v, want true and StateWon", tut.Done(), tut.Board.State)
+	}
+}
+
+func TestTutorialRun(t *testing.T) {
+	input := strings.Join([]string{
+		"reveal 1 1", // wrong, the hint is repeated
+		"reveal 2 2",
+		"nonsense",
+		"flag 4 3",
+		"flag 3 4",
+		"reveal 4 4",
+	}, "\n")
+	var out bytes.Buffer
+	tut := NewTutorial()
+	tut.Run(strings.NewReader(input), &out)
+
+	if !tut.Done() {
+		t.Fatalf("tutorial stopped at step %d", tut.Current)
+	}
+	if got := strings.Count(out.String(), tut.Steps[0].Hint); got != 1 {
+		t.Errorf("first hint shown %d times, want 1", got)
+	}
+	if got := strings.Count(out.String(), tut.Steps[1].Hint); got != 1 {
+		t.Errorf("second hint shown %d times, want 1", got)
+	}
+	if !strings.Contains(out.String(), "Congratulations") {
+		t.Error("tutorial didn't congratulate the player")
+	}
+}
+
+func TestTutorialRunQuit(t *testing.T) {
+	var out bytes.Buffer
+	tut := NewTutorial()
+	tut.Run(strings.NewReader("reveal 2 2\nquit\nflag 4 3\n"), &out)
+	if tut.Current != 1 || !strings.Contains(out.String(), "Quit tutorial.") {
+		t.Errorf("Current = %d after quitting at step 2, want 1", tut.Current)
+	}
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"time"
+)
+
+// watchInterval is how often --watch polls the save file
+const watchInterval = 500 * time.Millisecond
+
+// Watch polls the save file at path every interval and re-renders the board to out whenever the file changes, starting with its current contents.
+// Each render is headed by a timestamp. A save that fails to load is reported and skipped, the watch carries on until stop is closed.
+// It returns an error only if the file can't be found when the watch starts.
+func Watch(path string, out io.Writer, interval time.Duration, stop <-chan struct{}) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	renderSave(path, out)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return nil
+		case <-ticker.C:
+		}
+		current, err := os.Stat(path)
+		if err != nil || (current.ModTime().Equal(info.ModTime()) && current.Size() == info.Size()) {
+			continue
+		}
+		info = current
+		renderSave(path, out)
+	}
+}
+
+// renderSave prints the time and the board saved at path, or the reason it couldn't be loaded.
+func renderSave(path string, out io.Writer) {
+	fmt.Fprintf(out, "[%s] %s\n", time.Now().Format(time.TimeOnly), path)
+	board, err := LoadBoardFile(path)
+	if err != nil {
+		fmt.Fprintln(out, err)
+		return
+	}
+	board.PrintBoardToWriter(out, false)
+}
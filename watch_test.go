@@ -0,0 +1,97 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// syncBuffer is a bytes.Buffer that Watch can write to while the test reads it.
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (s *syncBuffer) Write(p []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.buf.Write(p)
+}
+
+func (s *syncBuffer) String() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.buf.String()
+}
+
+// waitFor polls until the output contains want, and fails the test if it doesn't within a second.
+func waitFor(t *testing.T, out *syncBuffer, want string) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for !strings.Contains(out.String(), want) {
+		if time.Now().After(deadline) {
+			t.Fatalf("output never contained %q:\n%s", want, out.String())
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestWatch(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "save.json")
+	b := boardFromTemplate(t, "M..\n...")
+	if err := b.SaveFile(path); err != nil {
+		t.Fatal(err)
+	}
+
+	out := &syncBuffer{}
+	stop := make(chan struct{})
+	done := make(chan error)
+	go func() { done <- Watch(path, out, 10*time.Millisecond, stop) }()
+	waitFor(t, out, ". . . \n. . . \n")
+
+	b.RevealCell(2, 1)
+	b.FlagCell(0, 0)
+	if err := b.SaveFile(path); err != nil {
+		t.Fatal(err)
+	}
+	// Make sure the change shows even on file systems with coarse timestamps
+	later := time.Now().Add(time.Minute)
+	if err := os.Chtimes(path, later, later); err != nil {
+		t.Fatal(err)
+	}
+	waitFor(t, out, "F 1 0 \n. 1 0 \n")
+
+	close(stop)
+	if err := <-done; err != nil {
+		t.Errorf("Watch() = %v, want nil", err)
+	}
+	if renders := strings.Count(out.String(), "] "+path); renders != 2 {
+		t.Errorf("rendered %d times, want 2:\n%s", renders, out.String())
+	}
+}
+
+func TestWatchBadSave(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "save.json")
+	if err := os.WriteFile(path, []byte("not a save"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	out := &syncBuffer{}
+	stop := make(chan struct{})
+	close(stop)
+	if err := Watch(path, out, time.Millisecond, stop); err != nil {
+		t.Errorf("Watch() = %v, want nil", err)
+	}
+	if strings.Contains(out.String(), ". . .") || !strings.Contains(out.String(), path) {
+		t.Errorf("a bad save should be reported, not rendered:\n%s", out.String())
+	}
+}
+
+func TestWatchMissingFile(t *testing.T) {
+	if err := Watch(filepath.Join(t.TempDir(), "missing.json"), &syncBuffer{}, time.Millisecond, nil); !os.IsNotExist(err) {
+		t.Errorf("Watch() on a missing file = %v, want a not-exist error", err)
+	}
+}
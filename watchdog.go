@@ -0,0 +1,40 @@
+package main
+
+import "fmt"
+
+// Watchdog struct guards a board's RevealCell against runaway flood fills.
+// A reveal can never visit more cells than the board has, so if it does the visited set is broken and the watchdog panics rather than letting the game hang.
+// This is a debug tool, enabled with the --debug flag.
+type Watchdog struct {
+	Board *Board
+
+	startX, startY int
+	visits         int
+	// maxVisits is the number of visits allowed per reveal, zero means one per cell on the board
+	maxVisits int
+}
+
+// NewWatchdog attaches a watchdog to the board. Every RevealCell call on the board is checked from then on.
+func NewWatchdog(b *Board) *Watchdog {
+	w := &Watchdog{Board: b}
+	b.watchdog = w
+	return w
+}
+
+// start resets the visit counter at the beginning of a RevealCell call.
+func (w *Watchdog) start(x, y int) {
+	w.startX, w.startY = x, y
+	w.visits = 0
+}
+
+// visit counts one cell processed by the current RevealCell call and panics once the count exceeds the board size.
+func (w *Watchdog) visit() {
+	w.visits++
+	limit := w.maxVisits
+	if limit == 0 {
+		limit = w.Board.Width * w.Board.Height
+	}
+	if w.visits > limit {
+		panic(fmt.Sprintf("watchdog: RevealCell(%d, %d) visited %d cells on a %dx%d board, the reveal is looping", w.startX, w.startY, w.visits, w.Board.Width, w.Board.Height))
+	}
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestWatchdogBudget(t *testing.T) {
+	tests := []struct {
+		name      string
+		maxVisits int
+		x, y      int
+		wantPanic bool
+	}{
+		{"board size", 0, 1, 2, false},
+		{"exactly enough", 11, 1, 2, false},
+		{"cascade over budget", 5, 1, 2, true},
+		{"single cell within budget", 1, 3, 0, false},
+		{"cascade one over budget", 10, 0, 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := boardFromTemplate(t, "...M\n....\n....")
+			w := NewWatchdog(b)
+			w.maxVisits = tt.maxVisits
+
+			defer func() {
+				r := recover()
+				if (r != nil) != tt.wantPanic {
+					t.Fatalf("RevealCell(%d, %d) panicked with %v, want panic %v", tt.x, tt.y, r, tt.wantPanic)
+				}
+				if want := fmt.Sprintf("RevealCell(%d, %d)", tt.x, tt.y); r != nil && !strings.Contains(fmt.Sprint(r), want) {
+					t.Errorf("panic %q doesn't mention %s", r, want)
+				}
+			}()
+			b.RevealCell(tt.x, tt.y)
+		})
+	}
+}
+
+func TestWatchdogQuietOnValidReveal(t *testing.T) {
+	b := boardFromTemplate(t, "........\n........\n........\n.......M")
+	w := NewWatchdog(b)
+	b.RevealCell(0, 0)
+	if w.visits == 0 || w.visits > b.Width*b.Height {
+		t.Errorf("watchdog counted %d visits on a %dx%d board", w.visits, b.Width, b.Height)
+	}
+	if b.State != StateWon {
+		t.Errorf("State = %v, want StateWon", b.State)
+	}
+}